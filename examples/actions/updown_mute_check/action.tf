@@ -0,0 +1,26 @@
+resource "updown_check" "api" {
+  url = "https://api.example.com/health"
+
+  lifecycle {
+    # Muted by action.updown_mute_check.deploy
+    ignore_changes = [mute_until, mute_for]
+  }
+}
+
+action "updown_mute_check" "deploy" {
+  config {
+    check    = updown_check.api.id
+    duration = "30m"
+  }
+}
+
+resource "terraform_data" "release" {
+  input = var.release
+
+  lifecycle {
+    action_trigger {
+      events  = [before_create, before_update]
+      actions = [action.updown_mute_check.deploy]
+    }
+  }
+}
@@ -0,0 +1,15 @@
+resource "updown_check" "api" {
+  url = "https://api.example.com/health"
+
+  lifecycle {
+    # Disabled by action.updown_set_check_enabled.disable_api
+    ignore_changes = [enabled]
+  }
+}
+
+action "updown_set_check_enabled" "disable_api" {
+  config {
+    check   = updown_check.api.id
+    enabled = false
+  }
+}
@@ -0,0 +1,16 @@
+action "updown_unmute_check" "deploy" {
+  config {
+    check = updown_check.api.id
+  }
+}
+
+resource "terraform_data" "release" {
+  input = var.release
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.updown_unmute_check.deploy]
+    }
+  }
+}
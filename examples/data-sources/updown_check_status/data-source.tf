@@ -0,0 +1,15 @@
+# Warn when the API isn't seen up by updown after applying
+check "api_up" {
+  data "updown_check_status" "api" {
+    token = updown_check.api.id
+
+    timeouts {
+      read = "2m"
+    }
+  }
+
+  assert {
+    condition     = !data.updown_check_status.api.down
+    error_message = "updown sees the API down: ${data.updown_check_status.api.error}"
+  }
+}
@@ -0,0 +1,8 @@
+list "updown_check" "enabled" {
+  provider = updown
+
+  config {
+    type    = "https"
+    enabled = true
+  }
+}
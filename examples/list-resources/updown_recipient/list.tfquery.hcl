@@ -0,0 +1,7 @@
+list "updown_recipient" "emails" {
+  provider = updown
+
+  config {
+    type = "email"
+  }
+}
@@ -0,0 +1,7 @@
+list "updown_status_page" "public" {
+  provider = updown
+
+  config {
+    visibility = "public"
+  }
+}
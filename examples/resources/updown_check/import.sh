@@ -2,3 +2,7 @@
 # It looks like the following regexp : ^https:\/\/updown.io\/([a-z0-9]{4})$
 
 terraform import updown_check.my_website <check_id>
+
+# Checks can also be imported by alias or URL, as long as a single check matches
+terraform import updown_check.checkout_api alias:checkout-api
+terraform import updown_check.health url:https://example.com/health
@@ -0,0 +1,3 @@
+# The ID is made of the check token and the recipient ID, separated by a slash
+
+terraform import updown_check_recipient.checkout_oncall ab12/webhook:123456789
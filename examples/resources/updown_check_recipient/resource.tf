@@ -0,0 +1,9 @@
+resource "updown_recipient" "oncall" {
+  type  = "webhook"
+  value = "https://pager.example.com/updown"
+}
+
+resource "updown_check_recipient" "checkout_oncall" {
+  check     = "ab12" # Token of a check managed elsewhere
+  recipient = updown_recipient.oncall.id
+}
@@ -0,0 +1,13 @@
+resource "updown_check" "api" {
+  url = "https://api.example.com/health"
+
+  lifecycle {
+    # Muted by updown_maintenance_window.release
+    ignore_changes = [mute_until, mute_for]
+  }
+}
+
+resource "updown_maintenance_window" "release" {
+  checks   = [updown_check.api.id, "ab12"]
+  duration = "45m"
+}
@@ -3,3 +3,8 @@
 # [{"id":"email:123456789","type":"email","name":"foo@bar.baz","immutable":false}]
 
 terraform import updown_recipient.my_recipient email:123456789
+
+# Recipients can also be imported by type and value, or by name, as long as a
+# single recipient matches
+terraform import updown_recipient.ops email:ops@example.com
+terraform import updown_recipient.oncall name:On-call
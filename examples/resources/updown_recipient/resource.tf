@@ -2,3 +2,11 @@ resource "updown_recipient" "myrecipient" {
   type = "email"
   value = "foo@bar.baz"
 }
+
+# Named recipient added to every existing check on creation
+resource "updown_recipient" "oncall" {
+  type     = "webhook"
+  value    = "https://example.com/oncall"
+  name     = "On-call"
+  selected = true
+}
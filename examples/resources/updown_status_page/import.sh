@@ -1 +1,4 @@
 terraform import updown_status_page.example <status_page_token>
+
+# Status pages can also be imported by name, as long as a single page matches
+terraform import updown_status_page.public "name:Public Status"
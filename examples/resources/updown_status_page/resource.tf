@@ -20,6 +20,32 @@ resource "updown_status_page" "protected" {
   ]
 }
 
+resource "updown_status_page" "generated" {
+  name                = "Partners"
+  visibility          = "protected"
+  access_key_rotation = "2024-01"
+
+  checks = [
+    updown_check.api.id,
+  ]
+}
+
+output "partners_access_key" {
+  value     = updown_status_page.generated.access_key
+  sensitive = true
+}
+
+# Checks can be referenced by alias, they must exist when planning
+resource "updown_status_page" "by_alias" {
+  name       = "Storefront"
+  visibility = "public"
+
+  check_aliases = [
+    "Website",
+    "API",
+  ]
+}
+
 resource "updown_status_page" "private" {
   name       = "Private Infrastructure"
   visibility = "private"
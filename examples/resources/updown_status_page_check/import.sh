@@ -0,0 +1,3 @@
+# The ID is made of the status page token and the check token, separated by a slash
+
+terraform import updown_status_page_check.checkout x1y2z3/ab12
@@ -0,0 +1,5 @@
+resource "updown_status_page_check" "checkout" {
+  status_page = "x1y2z3" # Token of the shared company status page
+  check       = updown_check.checkout.id
+  position    = 0
+}
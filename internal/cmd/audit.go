@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sergo-techhub/terraform-provider-updown/internal/tools"
+)
+
+func runAudit(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("audit", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	statePath := flags.String("state", "", "Terraform state `file` to compare with the account, e.g. the output of terraform state pull")
+	format := flags.String("format", "text", "write a `text` or json report")
+	detailedExitCode := flags.Bool("detailed-exitcode", false, "exit with 2 when the report isn't empty, like terraform plan")
+
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-updown audit -state <file> [options]\n\n")
+		fmt.Fprintf(stderr, "Reports the checks, recipients and status pages missing from the state, the ones deleted out of band, the attributes changed out of band and the recipients of checks which no longer exist.\n\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	if *statePath == "" || (*format != "text" && *format != "json") {
+		flags.Usage()
+		return 1
+	}
+
+	state, err := os.ReadFile(*statePath)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	client, err := newClient()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	report, err := tools.Audit(client, state)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	if *format == "json" {
+		b, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Fprintf(stdout, "%s\n", b)
+	} else {
+		writeAuditReport(stdout, report)
+	}
+
+	if *detailedExitCode && !report.Empty() {
+		return 2
+	}
+	return 0
+}
+
+func writeAuditReport(w io.Writer, report tools.AuditReport) {
+	if report.Empty() {
+		fmt.Fprintf(w, "The state matches the account.\n")
+		return
+	}
+
+	if len(report.Unmanaged) > 0 {
+		fmt.Fprintf(w, "Not managed by Terraform (%d):\n", len(report.Unmanaged))
+		for _, object := range report.Unmanaged {
+			fmt.Fprintf(w, "  %s %s (%s)\n", object.Type, object.ID, object.Description)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(report.Deleted) > 0 {
+		fmt.Fprintf(w, "Deleted outside of Terraform (%d):\n", len(report.Deleted))
+		for _, object := range report.Deleted {
+			fmt.Fprintf(w, "  %s (%s)\n", object.Address, object.ID)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(report.Drift) > 0 {
+		fmt.Fprintf(w, "Changed outside of Terraform (%d):\n", len(report.Drift))
+		for _, drift := range report.Drift {
+			state, _ := json.Marshal(drift.State)
+			remote, _ := json.Marshal(drift.Remote)
+			fmt.Fprintf(w, "  %s.%s: %s => %s\n", drift.Address, drift.Attribute, state, remote)
+		}
+		fmt.Fprintln(w)
+	}
+
+	if len(report.MissingRecipients) > 0 {
+		fmt.Fprintf(w, "Recipients which no longer exist (%d):\n", len(report.MissingRecipients))
+		for _, missing := range report.MissingRecipients {
+			fmt.Fprintf(w, "  %s: %s\n", missing.Address, missing.Recipient)
+		}
+		fmt.Fprintln(w)
+	}
+}
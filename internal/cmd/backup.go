@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sergo-techhub/terraform-provider-updown/internal/tools"
+)
+
+func runBackup(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("backup", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	output := flags.String("out", "", "`file` the backup is written to instead of the standard output")
+
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-updown backup [options]\n\n")
+		fmt.Fprintf(stderr, "Writes every check, recipient and status page of the account as a versioned JSON document, which restore recreates. The document holds secrets such as access keys and credentials embedded in URLs.\n\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	client, err := newClient()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	backup, err := tools.NewBackup(client)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	b, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	b = append(b, '\n')
+
+	if *output == "" {
+		_, err = stdout.Write(b)
+	} else {
+		// Readable by the owner only, as it holds secrets
+		err = os.WriteFile(*output, b, 0o600)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+func runRestore(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	dryRun := flags.Bool("dry-run", false, "print what would be created without creating anything")
+	resume := flags.Bool("resume", false, "resume a restore which failed midway, keeping the objects of the backup which already exist")
+
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-updown restore [options] <backup>\n\n")
+		fmt.Fprintf(stderr, "Recreates the checks, recipients and status pages of a backup in an empty account, rewiring the recipients of the checks and the checks of the status pages to their new IDs.\n\n")
+		fmt.Fprintf(stderr, "A restore which failed midway is resumed by running it again with -resume: recipients matching by type and value, checks by URL and alias, and status pages by name are kept instead of created again.\n\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	b, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	var backup tools.Backup
+	if err := json.Unmarshal(b, &backup); err != nil {
+		fmt.Fprintf(stderr, "Error: reading backup: %s\n", err)
+		return 1
+	}
+
+	client, err := newClient()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	if err := tools.Restore(client, backup, tools.RestoreOptions{DryRun: *dryRun, Resume: *resume}, stdout); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,73 @@
+// Package cmd implements the subcommands of the provider binary, which help
+// bringing an existing updown.io account under Terraform management. Without
+// a subcommand, the binary serves the provider to Terraform.
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/sergo-techhub/updown"
+)
+
+// command runs a subcommand with its arguments, returning the exit code.
+type command func(args []string, stdout, stderr io.Writer) int
+
+var commands = map[string]struct {
+	run         command
+	description string
+}{
+	"generate": {runGenerate, "Export the account as Terraform configuration and import blocks"},
+	"restore":  {runRestore, "Recreate a backup in an empty account"},
+	"audit":    {runAudit, "Compare a Terraform state with the account"},
+	"backup":   {runBackup, "Write the configuration of the account as a JSON document"},
+	"convert":  {runConvert, "Convert UptimeRobot, Pingdom or blackbox_exporter monitors into checks"},
+}
+
+// IsCommand tells whether the argument is a subcommand rather than a flag of
+// the provider server.
+func IsCommand(arg string) bool {
+	_, ok := commands[arg]
+	return ok || arg == "help"
+}
+
+// Run runs the subcommand named by the first argument, returning the exit
+// code.
+func Run(args []string, stdout, stderr io.Writer) int {
+	c, ok := commands[args[0]]
+	if !ok {
+		usage(stdout)
+		return 0
+	}
+	return c.run(args[1:], stdout, stderr)
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: terraform-provider-updown <command> [options]\n\nCommands:\n")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].description)
+	}
+	fmt.Fprintf(w, "\nThe commands calling the API read the API key from the UPDOWN_API_KEY environment variable.\n")
+}
+
+// newClient returns a client of the API authenticated the same way as the
+// provider.
+func newClient() (*updown.Client, error) {
+	apiKey := os.Getenv("UPDOWN_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("the UPDOWN_API_KEY environment variable must be set")
+	}
+
+	client := updown.NewClient(apiKey, nil)
+	client.SkipCache = true
+	return client, nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sergo-techhub/terraform-provider-updown/internal/tools"
+)
+
+func runConvert(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	from := flags.String("from", "", "tool the `export` comes from: uptimerobot, pingdom or blackbox")
+	modules := flags.String("modules", "", "blackbox_exporter configuration `file` defining the modules, with -from blackbox")
+	format := flags.String("format", "hcl", "write `hcl` or json configuration")
+	output := flags.String("out", "", "`file` the configuration is written to instead of the standard output")
+
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-updown convert -from <tool> [options] <export>\n\n")
+		fmt.Fprintf(stderr, "Converts the monitors exported from another tool into updown_check resources, without calling any API. The export is the JSON response of the UptimeRobot getMonitors or Pingdom /checks endpoints, or the Prometheus configuration scraping blackbox_exporter.\n\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 1 || *from == "" {
+		flags.Usage()
+		return 2
+	}
+
+	opts := tools.ConvertOptions{From: *from, Format: *format}
+
+	var err error
+	if opts.Export, err = os.ReadFile(flags.Arg(0)); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	if *from == "blackbox" {
+		if *modules == "" {
+			fmt.Fprintf(stderr, "Error: -modules is required with -from blackbox\n")
+			return 2
+		}
+		if opts.BlackboxModules, err = os.ReadFile(*modules); err != nil {
+			fmt.Fprintf(stderr, "Error: %s\n", err)
+			return 1
+		}
+	}
+
+	config, report, err := tools.Convert(opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	for _, line := range report {
+		fmt.Fprintf(stderr, "Warning: %s\n", line)
+	}
+
+	if *output == "" {
+		_, err = stdout.Write(config)
+	} else {
+		err = os.WriteFile(*output, config, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	return 0
+}
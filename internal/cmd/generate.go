@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/sergo-techhub/terraform-provider-updown/internal/tools"
+)
+
+func runGenerate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	outputDir := flags.String("out", ".", "directory the files are written to")
+	naming := flags.String("naming", "alias", "name the check resources after their `alias` (falling back to the URL) or their url")
+	filter := flags.String("filter", "", "only export the checks whose alias or URL, recipients whose name or value and status pages whose name match this `regexp`")
+	format := flags.String("format", "hcl", "write `hcl` (.tf) or json (.tf.json) files")
+	force := flags.Bool("force", false, "overwrite existing files")
+
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-updown generate [options]\n\n")
+		fmt.Fprintf(stderr, "Writes the configuration of every check, recipient and status page of the account, along with the import blocks bringing them under management.\n\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	opts := tools.GenerateOptions{Naming: *naming, Format: *format}
+	if *filter != "" {
+		re, err := regexp.Compile(*filter)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: invalid filter: %s\n", err)
+			return 2
+		}
+		opts.Filter = re
+	}
+
+	client, err := newClient()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	files, err := tools.Generate(client, opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	if err := writeFiles(*outputDir, files, *force); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(stdout, "Wrote %s\n", filepath.Join(*outputDir, name))
+	}
+	if len(files) == 0 {
+		fmt.Fprintf(stdout, "Nothing to export\n")
+	}
+
+	return 0
+}
+
+// writeFiles writes the files in the directory, refusing to overwrite
+// existing ones unless forced, as they may hold hand written configuration.
+func writeFiles(dir string, files map[string][]byte, force bool) error {
+	if !force {
+		for name := range files {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists, use -force to overwrite it", path)
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{"checks.tf": []byte("# generated\n")}
+
+	if err := os.WriteFile(filepath.Join(dir, "checks.tf"), []byte("# hand written\n"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := writeFiles(dir, files, false); err == nil {
+		t.Error("expected an error when overwriting an existing file")
+	}
+
+	if err := writeFiles(dir, files, true); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if b, _ := os.ReadFile(filepath.Join(dir, "checks.tf")); string(b) != "# generated\n" {
+		t.Errorf("expected the file to be overwritten, got %q", b)
+	}
+}
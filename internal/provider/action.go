@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/sergo-techhub/updown"
+)
+
+// checkAction implements what the actions on checks have in common.
+type checkAction struct {
+	meta *providerMeta
+}
+
+func (a *checkAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Not configured yet when validating
+	if req.ProviderData == nil {
+		return
+	}
+
+	meta, ok := req.ProviderData.(*providerMeta)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *providerMeta, got %T. This is always a bug in the provider.", req.ProviderData))
+		return
+	}
+	a.meta = meta
+}
+
+// update sends the payload updating a single field of the check, reporting
+// progress before and after.
+func (a *checkAction) update(ctx context.Context, token string, payload interface{}, progress string, resp *action.InvokeResponse) (updown.Check, bool) {
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("%s check %s", progress, token)})
+
+	// updown_check resources update the same checks
+	resourceLocks.Lock(checkLockKey(token))
+	defer resourceLocks.Unlock(checkLockKey(token))
+
+	check, err := updateCheck(ctx, a.meta.client, token, payload)
+	if err != nil {
+		resp.Diagnostics.AddError("Updating check "+token+" with the API", err.Error())
+		return updown.Check{}, false
+	}
+	return check, true
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// muteCheckAction mutes a check without changing the configuration of the
+// updown_check resource, e.g. around a deploy.
+type muteCheckAction struct {
+	checkAction
+}
+
+var (
+	_ action.ActionWithConfigure      = &muteCheckAction{}
+	_ action.ActionWithValidateConfig = &muteCheckAction{}
+)
+
+func newMuteCheckAction() action.Action {
+	return &muteCheckAction{}
+}
+
+type muteCheckModel struct {
+	Check    types.String `tfsdk:"check"`
+	Until    types.String `tfsdk:"until"`
+	Duration types.String `tfsdk:"duration"`
+}
+
+func (a *muteCheckAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mute_check"
+}
+
+func (a *muteCheckAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Mutes the notifications of a check, only updating its `mute_until`.",
+		Attributes: map[string]schema.Attribute{
+			"check": schema.StringAttribute{
+				Required:    true,
+				Description: "Token of the check.",
+			},
+			"until": schema.StringAttribute{
+				Optional:    true,
+				Description: "Mute notifications until given time, accepts a time, 'recovery', 'forever' or a duration relative to the time of the action such as '+45m'. Conflicts with `duration`.",
+			},
+			"duration": schema.StringAttribute{
+				Optional:    true,
+				Description: "Mute notifications for this duration (e.g. 45m or 2h) from the time of the action. Conflicts with `until`.",
+			},
+		},
+	}
+}
+
+func (a *muteCheckAction) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	var config muteCheckModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Until.IsUnknown() || config.Duration.IsUnknown() {
+		return
+	}
+
+	if config.Until.IsNull() == config.Duration.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("until"), "Invalid mute", "Exactly one of until or duration must be set.")
+		return
+	}
+
+	if _, err := muteCheckUntil(config, time.Now()); err != nil {
+		resp.Diagnostics.AddError("Invalid mute", err.Error())
+	}
+}
+
+// muteCheckUntil returns the mute_until sent to the API, durations being
+// resolved against now.
+func muteCheckUntil(m muteCheckModel, now time.Time) (string, error) {
+	duration := m.Duration.ValueString()
+	if m.Duration.IsNull() {
+		if !strings.HasPrefix(m.Until.ValueString(), "+") {
+			return m.Until.ValueString(), nil
+		}
+		duration = m.Until.ValueString()
+	}
+
+	muteFor, err := parseMuteDuration(duration)
+	if err != nil {
+		return "", err
+	}
+	return now.Add(muteFor).UTC().Format(time.RFC3339), nil
+}
+
+func (a *muteCheckAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config muteCheckModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	muteUntil, err := muteCheckUntil(config, time.Now())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid mute", err.Error())
+		return
+	}
+
+	token := config.Check.ValueString()
+	check, ok := a.update(ctx, token, checkMutePayload{MuteUntil: muteUntil}, "Muting", resp)
+	if !ok {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Check %s muted until %s", token, check.MuteUntil)})
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// setCheckEnabledAction enables or disables a check without changing the
+// configuration of the updown_check resource.
+type setCheckEnabledAction struct {
+	checkAction
+}
+
+var _ action.ActionWithConfigure = &setCheckEnabledAction{}
+
+func newSetCheckEnabledAction() action.Action {
+	return &setCheckEnabledAction{}
+}
+
+type setCheckEnabledModel struct {
+	Check   types.String `tfsdk:"check"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+func (a *setCheckEnabledAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_set_check_enabled"
+}
+
+func (a *setCheckEnabledAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Enables or disables a check, only updating its `enabled` flag.",
+		Attributes: map[string]schema.Attribute{
+			"check": schema.StringAttribute{
+				Required:    true,
+				Description: "Token of the check.",
+			},
+			"enabled": schema.BoolAttribute{
+				Required:    true,
+				Description: "Whether the check is enabled (true) or disabled (false).",
+			},
+		},
+	}
+}
+
+func (a *setCheckEnabledAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config setCheckEnabledModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token := config.Check.ValueString()
+	progress, done := "Disabling", "disabled"
+	if config.Enabled.ValueBool() {
+		progress, done = "Enabling", "enabled"
+	}
+
+	if _, ok := a.update(ctx, token, checkEnabledPayload{Enabled: config.Enabled.ValueBool()}, progress, resp); ok {
+		resp.SendProgress(action.InvokeProgressEvent{Message: "Check " + token + " " + done})
+	}
+}
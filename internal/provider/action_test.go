@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// invokeAction invokes the action with the configuration, returning the body
+// of the requests sent to the API and the progress messages.
+func invokeAction(t *testing.T, a action.Action, config map[string]tftypes.Value) ([]string, []string) {
+	t.Helper()
+	ctx := context.Background()
+
+	var bodies []string
+	client := newHandlerTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+		_, _ = w.Write([]byte(`{"token": "abcd", "mute_until": "2024-05-01T10:45:00Z"}`))
+	})
+	a.(action.ActionWithConfigure).Configure(ctx, action.ConfigureRequest{ProviderData: &providerMeta{client: client}}, &action.ConfigureResponse{})
+
+	var schema action.SchemaResponse
+	a.Schema(ctx, action.SchemaRequest{}, &schema)
+
+	values := map[string]tftypes.Value{}
+	for name, attribute := range schema.Schema.Attributes {
+		values[name] = tftypes.NewValue(attribute.GetType().TerraformType(ctx), nil)
+	}
+	for name, value := range config {
+		values[name] = value
+	}
+
+	var messages []string
+	resp := action.InvokeResponse{SendProgress: func(event action.InvokeProgressEvent) {
+		messages = append(messages, event.Message)
+	}}
+	a.Invoke(ctx, action.InvokeRequest{Config: tfsdk.Config{
+		Schema: schema.Schema,
+		Raw:    tftypes.NewValue(schema.Schema.Type().TerraformType(ctx), values),
+	}}, &resp)
+
+	for _, d := range resp.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", d.Summary(), d.Detail())
+	}
+	return bodies, messages
+}
+
+func TestMuteCheckAction(t *testing.T) {
+	bodies, messages := invokeAction(t, newMuteCheckAction(), map[string]tftypes.Value{
+		"check": tftypes.NewValue(tftypes.String, "abcd"),
+		"until": tftypes.NewValue(tftypes.String, "forever"),
+	})
+
+	if expected := `PUT /api/checks/abcd {"mute_until":"forever"}`; len(bodies) != 1 || bodies[0] != expected {
+		t.Errorf("got requests %q, expected %s", bodies, expected)
+	}
+	if expected := "Check abcd muted until 2024-05-01T10:45:00Z"; len(messages) != 2 || messages[1] != expected {
+		t.Errorf("got progress %q, expected %s last", messages, expected)
+	}
+}
+
+func TestMuteCheckUntil(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		model    muteCheckModel
+		expected string
+	}{
+		"until":          {muteCheckModel{Until: types.StringValue("recovery"), Duration: types.StringNull()}, "recovery"},
+		"relative until": {muteCheckModel{Until: types.StringValue("+45m"), Duration: types.StringNull()}, "2024-05-01T10:45:00Z"},
+		"duration":       {muteCheckModel{Until: types.StringNull(), Duration: types.StringValue("2h")}, "2024-05-01T12:00:00Z"},
+		"bad duration":   {muteCheckModel{Until: types.StringNull(), Duration: types.StringValue("soon")}, ""},
+	} {
+		got, err := muteCheckUntil(tc.model, now)
+		if tc.expected == "" {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %q", name, got)
+			}
+		} else if err != nil || got != tc.expected {
+			t.Errorf("%s: got %q (%v), expected %q", name, got, err, tc.expected)
+		}
+	}
+}
+
+func TestUnmuteCheckAction(t *testing.T) {
+	bodies, _ := invokeAction(t, newUnmuteCheckAction(), map[string]tftypes.Value{
+		"check": tftypes.NewValue(tftypes.String, "abcd"),
+	})
+
+	if expected := `PUT /api/checks/abcd {"mute_until":""}`; len(bodies) != 1 || bodies[0] != expected {
+		t.Errorf("got requests %q, expected %s", bodies, expected)
+	}
+}
+
+func TestSetCheckEnabledAction(t *testing.T) {
+	bodies, messages := invokeAction(t, newSetCheckEnabledAction(), map[string]tftypes.Value{
+		"check":   tftypes.NewValue(tftypes.String, "abcd"),
+		"enabled": tftypes.NewValue(tftypes.Bool, false),
+	})
+
+	if expected := `PUT /api/checks/abcd {"enabled":false}`; len(bodies) != 1 || bodies[0] != expected {
+		t.Errorf("got requests %q, expected %s", bodies, expected)
+	}
+	if expected := []string{"Disabling check abcd", "Check abcd disabled"}; strings.Join(messages, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("got progress %q, expected %q", messages, expected)
+	}
+}
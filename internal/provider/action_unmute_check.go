@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// unmuteCheckAction unmutes a check without changing the configuration of the
+// updown_check resource.
+type unmuteCheckAction struct {
+	checkAction
+}
+
+var _ action.ActionWithConfigure = &unmuteCheckAction{}
+
+func newUnmuteCheckAction() action.Action {
+	return &unmuteCheckAction{}
+}
+
+type unmuteCheckModel struct {
+	Check types.String `tfsdk:"check"`
+}
+
+func (a *unmuteCheckAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_unmute_check"
+}
+
+func (a *unmuteCheckAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Unmutes the notifications of a check, only clearing its `mute_until`.",
+		Attributes: map[string]schema.Attribute{
+			"check": schema.StringAttribute{
+				Required:    true,
+				Description: "Token of the check.",
+			},
+		},
+	}
+}
+
+func (a *unmuteCheckAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config unmuteCheckModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token := config.Check.ValueString()
+	if _, ok := a.update(ctx, token, checkMutePayload{}, "Unmuting", resp); ok {
+		resp.SendProgress(action.InvokeProgressEvent{Message: "Check " + token + " unmuted"})
+	}
+}
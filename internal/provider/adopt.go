@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sergo-techhub/updown"
+)
+
+// adoptExistingSchema is shared by the resources able to take ownership of an
+// existing object instead of creating a duplicate.
+func adoptExistingSchema(match string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Description: "Take ownership of an existing object with the same " + match + " on create instead of creating a duplicate. " +
+			"Defaults to the `adopt_existing` provider argument.",
+	}
+}
+
+// shouldAdoptExisting tells whether the resource adopts existing objects, as
+// configured on the resource or else on the provider.
+func shouldAdoptExisting(d *schema.ResourceData, meta interface{}) bool {
+	if v := d.GetRawConfig().GetAttr("adopt_existing"); v.IsKnown() && !v.IsNull() {
+		return v.True()
+	}
+	return meta.(*providerMeta).adoptExisting
+}
+
+// adoptedWarning tells the user an existing object is now managed by
+// Terraform, so that it doesn't go unnoticed.
+func adoptedWarning(kind, id, description string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Adopted existing %s %s", kind, id),
+		Detail:   fmt.Sprintf("The %s %s (%s) already existed, it is now managed by Terraform instead of being created again.", kind, id, description),
+	}}
+}
+
+// checkAdoptionURL strips what differs between the URL of the configuration
+// and the one returned by the API.
+func checkAdoptionURL(rawURL string) string {
+	rawURL, _, _ = stripCheckURLCredentials(rawURL)
+	return strings.TrimPrefix(rawURL, "icmp://")
+}
+
+// findAdoptableCheck looks for the check with the URL and alias. It fails
+// when several of them match, as picking one would be arbitrary.
+func findAdoptableCheck(checks []updown.Check, rawURL, alias string) (updown.Check, bool, error) {
+	var matches []updown.Check
+	for _, check := range checks {
+		if checkAdoptionURL(check.URL) == checkAdoptionURL(rawURL) && check.Alias == alias {
+			matches = append(matches, check)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return updown.Check{}, false, nil
+	case 1:
+		return matches[0], true, nil
+	}
+
+	tokens := make([]string, 0, len(matches))
+	for _, check := range matches {
+		tokens = append(tokens, check.Token)
+	}
+	return updown.Check{}, false, fmt.Errorf("several checks match url %q and alias %q (%s), import the one to manage instead", rawURL, alias, strings.Join(tokens, ", "))
+}
+
+// findAdoptableRecipient looks for the recipient with the type and value,
+// ignoring the formatting differences with the API. It fails when several of
+// them match, as picking one would be arbitrary.
+func findAdoptableRecipient(recipients []updown.Recipient, recipientType, value string) (updown.Recipient, bool, error) {
+	var matches []updown.Recipient
+	for _, r := range recipients {
+		stored := r.Value
+		if stored == "" {
+			stored = r.Name
+		}
+
+		if string(r.Type) == recipientType && normalizeRecipientValue(recipientType, stored) == normalizeRecipientValue(recipientType, value) {
+			matches = append(matches, r)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return updown.Recipient{}, false, nil
+	case 1:
+		return matches[0], true, nil
+	}
+
+	ids := make([]string, 0, len(matches))
+	for _, r := range matches {
+		ids = append(ids, r.ID)
+	}
+	return updown.Recipient{}, false, fmt.Errorf("several %s recipients match value %q (%s), import the one to manage instead", recipientType, value, strings.Join(ids, ", "))
+}
+
+// findAdoptableStatusPage looks for the status page with the name. It fails
+// when several of them match, as picking one would be arbitrary.
+func findAdoptableStatusPage(statusPages []updown.StatusPage, name string) (updown.StatusPage, bool, error) {
+	var matches []updown.StatusPage
+	for _, statusPage := range statusPages {
+		if statusPage.Name == name {
+			matches = append(matches, statusPage)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return updown.StatusPage{}, false, nil
+	case 1:
+		return matches[0], true, nil
+	}
+
+	tokens := make([]string, 0, len(matches))
+	for _, statusPage := range matches {
+		tokens = append(tokens, statusPage.Token)
+	}
+	return updown.StatusPage{}, false, fmt.Errorf("several status pages are named %q (%s), import the one to manage instead", name, strings.Join(tokens, ", "))
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sergo-techhub/updown"
+)
+
+// The functions below are the equivalent of the ones of updown.Client, the
+// requests being cancelled along with ctx, e.g. on Ctrl-C or once the timeout
+// of the operation is reached.
+
+// apiRequest sends the request to the API, decoding the response into v.
+func apiRequest(ctx context.Context, client *updown.Client, method, path string, body, v interface{}) error {
+	req, err := client.NewRequest(method, path, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Do(req.WithContext(ctx), v)
+	return err
+}
+
+// noCachePath defeats the caches between the provider and the API, which is
+// what the SkipCache option of updown.Client does.
+func noCachePath(path string) string {
+	return fmt.Sprintf("%s?_=%d", path, time.Now().UnixNano())
+}
+
+// removeResponse is the response of the DELETE endpoints.
+type removeResponse struct {
+	Deleted bool `json:"deleted"`
+}
+
+// checkPayload overrides the recipients of updown.CheckItem, which are omitted
+// when empty, so that detaching every recipient can be expressed. A nil
+// RecipientIDs leaves the recipients of the check untouched.
+type checkPayload struct {
+	updown.CheckItem
+	RecipientIDs *[]string `json:"recipients,omitempty"`
+}
+
+// listChecks is the equivalent of client.Check.List.
+func listChecks(ctx context.Context, client *updown.Client) ([]updown.Check, error) {
+	var checks []updown.Check
+	err := apiRequest(ctx, client, "GET", "checks", nil, &checks)
+	return checks, err
+}
+
+// addCheck is the equivalent of client.Check.Add for a checkPayload.
+func addCheck(ctx context.Context, client *updown.Client, payload checkPayload) (updown.Check, error) {
+	var check updown.Check
+	err := apiRequest(ctx, client, "POST", "checks", payload, &check)
+	return check, err
+}
+
+// getCheck is the equivalent of client.Check.Get.
+func getCheck(ctx context.Context, client *updown.Client, token string) (updown.Check, error) {
+	var check updown.Check
+	err := apiRequest(ctx, client, "GET", noCachePath("checks/"+token), nil, &check)
+	return check, err
+}
+
+// checkRecipientsPayload only updates the recipients of a check, unlike
+// updown.CheckItem which always sends enabled and published.
+type checkRecipientsPayload struct {
+	RecipientIDs []string `json:"recipients"`
+}
+
+// checkMutePayload only updates mute_until, an empty value unmuting the check.
+type checkMutePayload struct {
+	MuteUntil string `json:"mute_until"`
+}
+
+// checkEnabledPayload only enables or disables a check.
+type checkEnabledPayload struct {
+	Enabled bool `json:"enabled"`
+}
+
+// updateCheck is the equivalent of client.Check.Update for a checkPayload or
+// one of the payloads updating a single field.
+func updateCheck(ctx context.Context, client *updown.Client, token string, payload interface{}) (updown.Check, error) {
+	var check updown.Check
+	err := apiRequest(ctx, client, "PUT", "checks/"+token, payload, &check)
+	return check, err
+}
+
+// removeCheck is the equivalent of client.Check.Remove.
+func removeCheck(ctx context.Context, client *updown.Client, token string) (bool, error) {
+	var res removeResponse
+	err := apiRequest(ctx, client, "DELETE", "checks/"+token, nil, &res)
+	return res.Deleted, err
+}
+
+// statusPagePayload overrides the checks of updown.StatusPageItem, which are
+// omitted when empty, so that removing every check can be expressed. A nil
+// Checks leaves the checks of the page untouched.
+type statusPagePayload struct {
+	updown.StatusPageItem
+	Checks *[]string `json:"checks,omitempty"`
+}
+
+// listStatusPages is the equivalent of client.StatusPage.List.
+func listStatusPages(ctx context.Context, client *updown.Client) ([]updown.StatusPage, error) {
+	var statusPages []updown.StatusPage
+	err := apiRequest(ctx, client, "GET", noCachePath("status_pages"), nil, &statusPages)
+	return statusPages, err
+}
+
+// addStatusPage is the equivalent of client.StatusPage.Add for a
+// statusPagePayload.
+func addStatusPage(ctx context.Context, client *updown.Client, payload statusPagePayload) (updown.StatusPage, error) {
+	var statusPage updown.StatusPage
+	err := apiRequest(ctx, client, "POST", "status_pages", payload, &statusPage)
+	return statusPage, err
+}
+
+// updateStatusPage is the equivalent of client.StatusPage.Update for a
+// statusPagePayload.
+func updateStatusPage(ctx context.Context, client *updown.Client, token string, payload statusPagePayload) (updown.StatusPage, error) {
+	var statusPage updown.StatusPage
+	err := apiRequest(ctx, client, "PUT", "status_pages/"+token, payload, &statusPage)
+	return statusPage, err
+}
+
+// removeStatusPage is the equivalent of client.StatusPage.Remove.
+func removeStatusPage(ctx context.Context, client *updown.Client, token string) (bool, error) {
+	var res removeResponse
+	err := apiRequest(ctx, client, "DELETE", "status_pages/"+token, nil, &res)
+	return res.Deleted, err
+}
+
+// findStatusPage looks the status page up in the list, as the API has no
+// endpoint to get a single one. The boolean is false when it doesn't exist.
+func findStatusPage(ctx context.Context, client *updown.Client, token string) (updown.StatusPage, bool, error) {
+	statusPages, err := listStatusPages(ctx, client)
+	if err != nil {
+		return updown.StatusPage{}, false, err
+	}
+
+	for _, statusPage := range statusPages {
+		if statusPage.Token == token {
+			return statusPage, true, nil
+		}
+	}
+
+	return updown.StatusPage{}, false, nil
+}
+
+// getStatusPage is the equivalent of client.StatusPage.Get, failing when the
+// status page doesn't exist.
+func getStatusPage(ctx context.Context, client *updown.Client, token string) (updown.StatusPage, error) {
+	statusPage, found, err := findStatusPage(ctx, client, token)
+	if err == nil && !found {
+		err = fmt.Errorf("status page with token %s not found", token)
+	}
+	return statusPage, err
+}
+
+// recipientPayload adds the selected flag of the API, which attaches the new
+// recipient to every existing check, to updown.RecipientItem.
+type recipientPayload struct {
+	updown.RecipientItem
+	Selected bool `json:"selected,omitempty"`
+}
+
+// listRecipients is the equivalent of client.Recipient.List.
+func listRecipients(ctx context.Context, client *updown.Client) ([]updown.Recipient, error) {
+	var recipients []updown.Recipient
+	err := apiRequest(ctx, client, "GET", noCachePath("recipients"), nil, &recipients)
+	return recipients, err
+}
+
+// addRecipient is the equivalent of client.Recipient.Add for a
+// recipientPayload.
+func addRecipient(ctx context.Context, client *updown.Client, payload recipientPayload) (updown.Recipient, error) {
+	var recipient updown.Recipient
+	err := apiRequest(ctx, client, "POST", "recipients", payload, &recipient)
+	return recipient, err
+}
+
+// removeRecipient is the equivalent of client.Recipient.Remove.
+func removeRecipient(ctx context.Context, client *updown.Client, id string) (bool, error) {
+	var res removeResponse
+	err := apiRequest(ctx, client, "DELETE", "recipients/"+id, nil, &res)
+	return res.Deleted, err
+}
+
+// listNodeIPs is the equivalent of client.Node.ListIPv4 and ListIPv6, version
+// being 4 or 6.
+func listNodeIPs(ctx context.Context, client *updown.Client, version int) (updown.IPs, error) {
+	var ips updown.IPs
+	err := apiRequest(ctx, client, "GET", fmt.Sprintf("nodes/ipv%d", version), nil, &ips)
+	return ips, err
+}
+
+// isNotFound tells whether the API responded with a 404.
+func isNotFound(err error) bool {
+	var errResp *updown.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound
+}
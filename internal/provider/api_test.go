@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sergo-techhub/updown"
+)
+
+func TestCheckPayload_recipients(t *testing.T) {
+	item := updown.CheckItem{URL: "https://example.com", RecipientIDs: []string{"email:1"}}
+
+	for expected, recipientIDs := range map[string]*[]string{
+		`"recipients":[]`:          {},
+		`"recipients":["email:2"]`: {"email:2"},
+		``:                         nil,
+	} {
+		b, err := json.Marshal(checkPayload{CheckItem: item, RecipientIDs: recipientIDs})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if expected == "" && strings.Contains(string(b), "recipients") {
+			t.Errorf("expected recipients to be omitted, got %s", b)
+		} else if !strings.Contains(string(b), expected) {
+			t.Errorf("expected %s in %s", expected, b)
+		}
+	}
+}
+
+func TestRecipientPayload_selected(t *testing.T) {
+	item := updown.RecipientItem{Type: updown.RecipientTypeEmail, Value: "foo@bar.baz", Name: "Foo"}
+
+	for expected, selected := range map[string]bool{
+		`{"type":"email","value":"foo@bar.baz","name":"Foo","selected":true}`: true,
+		`{"type":"email","value":"foo@bar.baz","name":"Foo"}`:                 false,
+	} {
+		b, err := json.Marshal(recipientPayload{RecipientItem: item, Selected: selected})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if string(b) != expected {
+			t.Errorf("got %s, expected %s", b, expected)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/sergo-techhub/updown"
+)
+
+// checkStatusPollInterval is the interval between two reads of a check
+// waited for.
+var checkStatusPollInterval = 5 * time.Second
+
+// checkStatuses are the values of wait_for_status: up waits for the check to
+// be up, any for a result whatever it is.
+var checkStatuses = []string{"up", "any"}
+
+func checkStatusDataSource() *schema.Resource {
+	return &schema.Resource{
+		Description: "`updown_check_status` data source reads the status of a check, waiting for it to be up (or to have a result at all) first, e.g. to gate on a deployed service being healthy in `check` blocks.",
+		ReadContext: checkStatusRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"token": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Token of the check.",
+			},
+			"wait_for_status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "up",
+				Description:  "Status to wait for: `up` waits for the check to be up, `any` only for a first result. Fails once the read timeout is reached.",
+				ValidateFunc: validation.StringInSlice(checkStatuses, false),
+			},
+			"down": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the check is down.",
+			},
+			"down_since": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the check has been down since.",
+			},
+			"error": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Error of the last check.",
+			},
+			"last_status": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "HTTP status of the last check.",
+			},
+			"last_check_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the last check.",
+			},
+			"uptime": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Uptime percentage over the last 30 days.",
+			},
+		},
+	}
+}
+
+func checkStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	token := d.Get("token").(string)
+
+	// Any result of the check counts, unlike after updating it
+	check, err := waitForCheckStatus(ctx, client, token, d.Get("wait_for_status").(string), "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(token)
+
+	for k, v := range map[string]interface{}{
+		"down":          check.Down,
+		"down_since":    check.DownSince,
+		"error":         check.Error,
+		"last_status":   check.LastStatus,
+		"last_check_at": check.LastCheckAt,
+		"uptime":        check.Uptime,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// waitForCheckStatus polls the check until it has a result more recent than
+// lastCheckAt, which must be up when waiting for the up status. It stops when
+// ctx is done, the deadline being the timeout of the resource.
+func waitForCheckStatus(ctx context.Context, client *updown.Client, token, status, lastCheckAt string) (updown.Check, error) {
+	ticker := time.NewTicker(checkStatusPollInterval)
+	defer ticker.Stop()
+
+	// Last check read, reported on timeout
+	var check updown.Check
+	for {
+		current, err := getCheck(ctx, client, token)
+		switch {
+		case ctx.Err() != nil:
+			// Interrupted request
+		case err != nil:
+			return current, fmt.Errorf("reading check %s from the API: %w", token, err)
+		case current.LastCheckAt != "" && current.LastCheckAt != lastCheckAt && (status == "any" || !current.Down):
+			return current, nil
+		default:
+			check = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return check, checkStatusTimeoutError(ctx, token, check, lastCheckAt)
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkStatusTimeoutError explains why waiting for the check stopped.
+func checkStatusTimeoutError(ctx context.Context, token string, check updown.Check, lastCheckAt string) error {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("waiting for check %s: %w", token, ctx.Err())
+	}
+
+	switch {
+	case check.LastCheckAt == "" || check.LastCheckAt == lastCheckAt || !check.Down:
+		return fmt.Errorf("timeout while waiting for a new result of check %s, make sure it's enabled", token)
+	case check.Error != "":
+		return fmt.Errorf("timeout while waiting for check %s to be up, it's down since %s: %s", token, check.DownSince, check.Error)
+	default:
+		return fmt.Errorf("timeout while waiting for check %s to be up, it's down since %s", token, check.DownSince)
+	}
+}
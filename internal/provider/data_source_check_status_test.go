@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sergo-techhub/updown"
+)
+
+// newCheckStatusTestClient returns the checks one after the other on every
+// read, the last one being repeated.
+func newCheckStatusTestClient(t *testing.T, checks ...updown.Check) *updown.Client {
+	interval := checkStatusPollInterval
+	checkStatusPollInterval = time.Millisecond
+	t.Cleanup(func() { checkStatusPollInterval = interval })
+
+	var mu sync.Mutex
+	return newHandlerTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		check := checks[0]
+		if len(checks) > 1 {
+			checks = checks[1:]
+		}
+		_ = json.NewEncoder(w).Encode(check)
+	})
+}
+
+func TestWaitForCheckStatus(t *testing.T) {
+	checks := []updown.Check{
+		{Token: "abcd", LastCheckAt: "2024-05-01T10:00:00Z", Down: false},
+		{Token: "abcd", LastCheckAt: "2024-05-01T10:01:00Z", Down: true, Error: "Connection refused"},
+		{Token: "abcd", LastCheckAt: "2024-05-01T10:02:00Z", Down: false},
+	}
+
+	for status, expected := range map[string]string{
+		"any": "2024-05-01T10:01:00Z",
+		"up":  "2024-05-01T10:02:00Z",
+	} {
+		client := newCheckStatusTestClient(t, checks...)
+
+		check, err := waitForCheckStatus(context.Background(), client, "abcd", status, "2024-05-01T10:00:00Z")
+		if err != nil || check.LastCheckAt != expected {
+			t.Errorf("%s: got result of %s (%v), expected %s", status, check.LastCheckAt, err, expected)
+		}
+	}
+
+	// Any result counts without a previous one
+	client := newCheckStatusTestClient(t, checks...)
+	if check, err := waitForCheckStatus(context.Background(), client, "abcd", "up", ""); err != nil || check.LastCheckAt != "2024-05-01T10:00:00Z" {
+		t.Errorf("got result of %s (%v), expected the first one", check.LastCheckAt, err)
+	}
+}
+
+func TestWaitForCheckStatus_timeout(t *testing.T) {
+	for name, tc := range map[string]struct {
+		check    updown.Check
+		status   string
+		expected string
+	}{
+		"no result": {
+			check:    updown.Check{Token: "abcd"},
+			status:   "any",
+			expected: "timeout while waiting for a new result of check abcd",
+		},
+		"down": {
+			check:    updown.Check{Token: "abcd", LastCheckAt: "2024-05-01T10:01:00Z", Down: true, DownSince: "2024-05-01T09:00:00Z", Error: "Connection refused"},
+			status:   "up",
+			expected: "timeout while waiting for check abcd to be up, it's down since 2024-05-01T09:00:00Z: Connection refused",
+		},
+	} {
+		client := newCheckStatusTestClient(t, tc.check)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, err := waitForCheckStatus(ctx, client, "abcd", tc.status, "")
+		cancel()
+
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("%s: expected error containing %q, got %v", name, tc.expected, err)
+		}
+	}
+
+	// Cancelled rather than timed out
+	client := newCheckStatusTestClient(t, updown.Check{Token: "abcd"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := waitForCheckStatus(ctx, client, "abcd", "any", ""); err == nil || !strings.Contains(err.Error(), "context canceled") {
+		t.Errorf("expected a cancellation error, got %v", err)
+	}
+}
@@ -1,16 +1,16 @@
 package provider
 
 import (
-	"fmt"
+	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/sergo-techhub/updown"
 )
 
 func nodesDataSource() *schema.Resource {
 	return &schema.Resource{
 		Description: "`updown_nodes` data source can be used to retrieve the IP addresses of their servers.",
-		Read:        nodesList,
+		ReadContext: nodesList,
 
 		Schema: map[string]*schema.Schema{
 			"ipv4": {
@@ -33,17 +33,17 @@ func nodesDataSource() *schema.Resource {
 	}
 }
 
-func nodesList(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*updown.Client)
+func nodesList(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
 
-	ipv4, _, err := client.Node.ListIPv4()
+	ipv4, err := listNodeIPs(ctx, client, 4)
 	if err != nil {
-		return fmt.Errorf("reading ipv4 addresses from API")
+		return diag.Errorf("reading ipv4 addresses from API")
 	}
 
-	ipv6, _, err := client.Node.ListIPv6()
+	ipv6, err := listNodeIPs(ctx, client, 6)
 	if err != nil {
-		return fmt.Errorf("reading ipv6 addresses from API")
+		return diag.Errorf("reading ipv6 addresses from API")
 	}
 
 	d.SetId("updown.io/nodes")
@@ -53,7 +53,7 @@ func nodesList(d *schema.ResourceData, meta interface{}) error {
 		"ipv6": ipv6,
 	} {
 		if err := d.Set(k, v); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sergo-techhub/updown"
+)
+
+// The functions below expose the resources to the subcommands of the
+// provider binary, so that the configurations they generate and compare are
+// read from the API the same way the resources do.
+
+// CheckResource returns the updown_check resource.
+func CheckResource() *schema.Resource {
+	return checkResource()
+}
+
+// RecipientResource returns the updown_recipient resource.
+func RecipientResource() *schema.Resource {
+	return recipientResource()
+}
+
+// StatusPageResource returns the updown_status_page resource.
+func StatusPageResource() *schema.Resource {
+	return statusPageResource()
+}
+
+// SetCheckData stores the check returned by the API in the data of an
+// updown_check resource.
+func SetCheckData(ctx context.Context, client *updown.Client, d *schema.ResourceData, check updown.Check) error {
+	return setCheckData(ctx, d, &providerMeta{client: client}, check)
+}
+
+// SetRecipientData stores the recipient returned by the API in the data of an
+// updown_recipient resource.
+func SetRecipientData(d *schema.ResourceData, r updown.Recipient) error {
+	return setRecipientData(d, r)
+}
+
+// SetStatusPageData stores the status page returned by the API in the data of
+// an updown_status_page resource.
+func SetStatusPageData(ctx context.Context, client *updown.Client, d *schema.ResourceData, statusPage updown.StatusPage) error {
+	return setStatusPageData(ctx, d, &providerMeta{client: client}, statusPage)
+}
+
+// ManagedRecipientTypes returns the types of recipients which can be set up
+// through the API, the other ones require the web UI.
+func ManagedRecipientTypes() []string {
+	return slices.Clone(managedRecipientTypes)
+}
+
+// CheckDisplayURL returns the URL of the check without its credentials and
+// the prefix the API adds to ICMP hosts.
+func CheckDisplayURL(rawURL string) string {
+	return checkAdoptionURL(rawURL)
+}
+
+// InferCheckType returns the check type matching the scheme of the URL, or an
+// empty string when it has none.
+func InferCheckType(rawURL string) string {
+	return inferCheckType(rawURL)
+}
+
+// ClosestCheckPeriod returns the shortest period supported by the API not
+// checking more often than the interval, or the longest one for longer
+// intervals.
+func ClosestCheckPeriod(seconds int) int {
+	return closestCheckPeriod(seconds)
+}
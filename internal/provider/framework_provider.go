@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// frameworkProvider serves what the SDK can't (list resources, functions,
+// actions and resources relying on private state), muxed with the SDK provider which
+// still serves the other resources and data sources. Both share the same
+// configuration.
+type frameworkProvider struct{}
+
+var (
+	_ fwprovider.ProviderWithListResources = &frameworkProvider{}
+	_ fwprovider.ProviderWithFunctions     = &frameworkProvider{}
+	_ fwprovider.ProviderWithActions       = &frameworkProvider{}
+)
+
+// NewFramework returns the terraform-plugin-framework part of the provider
+func NewFramework() func() fwprovider.Provider {
+	return func() fwprovider.Provider {
+		return &frameworkProvider{}
+	}
+}
+
+type frameworkProviderModel struct {
+	APIKey              types.String `tfsdk:"api_key"`
+	AllowPrivateTargets types.Bool   `tfsdk:"allow_private_targets"`
+	AdoptExisting       types.Bool   `tfsdk:"adopt_existing"`
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "updown"
+}
+
+// Schema mirrors the SDK provider schema, as both have to be identical to be
+// muxed.
+func (p *frameworkProvider) Schema(_ context.Context, _ fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	sdkSchema := New()().Schema
+
+	resp.Schema = fwschema.Schema{
+		Attributes: map[string]fwschema.Attribute{
+			"api_key": fwschema.StringAttribute{
+				Optional:    true, // Required with an environment variable fallback
+				Description: sdkSchema["api_key"].Description,
+			},
+			"allow_private_targets": fwschema.BoolAttribute{
+				Optional:    true,
+				Description: sdkSchema["allow_private_targets"].Description,
+			},
+			"adopt_existing": fwschema.BoolAttribute{
+				Optional:    true,
+				Description: sdkSchema["adopt_existing"].Description,
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	var config frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiKey := config.APIKey.ValueString()
+	if apiKey == "" {
+		apiKey = os.Getenv("UPDOWN_API_KEY")
+	}
+
+	// Same defaults as the SDK provider
+	allowPrivateTargets := true
+	if !config.AllowPrivateTargets.IsNull() {
+		allowPrivateTargets = config.AllowPrivateTargets.ValueBool()
+	}
+
+	meta := newProviderMeta(apiKey, allowPrivateTargets, config.AdoptExisting.ValueBool())
+	resp.ResourceData = meta
+	resp.ActionData = meta
+	resp.ListResourceData = meta
+}
+
+func (p *frameworkProvider) Resources(context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newMaintenanceWindowResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) ListResources(context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		newCheckListResource,
+		newRecipientListResource,
+		newStatusPageListResource,
+	}
+}
+
+func (p *frameworkProvider) Functions(context.Context) []func() function.Function {
+	return []func() function.Function{
+		newCheckTypeFunction,
+		newMuteUntilFunction,
+		newNormalizeURLFunction,
+		newPeriodFunction,
+	}
+}
+
+func (p *frameworkProvider) Actions(context.Context) []func() action.Action {
+	return []func() action.Action{
+		newMuteCheckAction,
+		newSetCheckEnabledAction,
+		newUnmuteCheckAction,
+	}
+}
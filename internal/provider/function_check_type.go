@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// checkTypeFunction exposes the inference of the check type from the URL
+// scheme, done by updown_check when `type` isn't set.
+type checkTypeFunction struct{}
+
+var _ function.Function = &checkTypeFunction{}
+
+func newCheckTypeFunction() function.Function {
+	return &checkTypeFunction{}
+}
+
+func (f *checkTypeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "check_type"
+}
+
+func (f *checkTypeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Infers the type of a check from its URL",
+		MarkdownDescription: "Returns the type of check (`http`, `https`, `icmp`, `tcp` or `tcps`) matching the scheme of the URL, the same way `updown_check` does when `type` isn't set. Fails for URLs without a supported scheme.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "url",
+				Description: "URL of the check.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *checkTypeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rawURL string
+	resp.Error = req.Arguments.Get(ctx, &rawURL)
+	if resp.Error != nil {
+		return
+	}
+
+	checkType := inferCheckType(rawURL)
+	if checkType == "" {
+		resp.Error = function.NewArgumentFuncError(0, "the URL has no http, https, icmp, tcp or tcps scheme, ICMP checks of bare hosts need type = \"icmp\"")
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, checkType)
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// muteUntilFunction turns a relative duration into the absolute time expected
+// by `mute_until`.
+type muteUntilFunction struct{}
+
+var _ function.Function = &muteUntilFunction{}
+
+func newMuteUntilFunction() function.Function {
+	return &muteUntilFunction{}
+}
+
+func (f *muteUntilFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "mute_until"
+}
+
+func (f *muteUntilFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Converts a relative duration to a mute_until value",
+		MarkdownDescription: "Returns the RFC 3339 time the duration (e.g. `45m` or `+2h`) after the `from` time, which should be `plantimestamp()` for the result to stay the same during the whole run. " +
+			"`recovery` and `forever` are returned as is.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "duration",
+				Description: "How long to mute the check for, e.g. 45m or +2h, or recovery or forever.",
+			},
+			function.StringParameter{
+				Name:        "from",
+				Description: "RFC 3339 time the duration is added to, usually plantimestamp().",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *muteUntilFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var duration, from string
+	resp.Error = req.Arguments.Get(ctx, &duration, &from)
+	if resp.Error != nil {
+		return
+	}
+
+	if duration == "recovery" || duration == "forever" {
+		resp.Error = resp.Result.Set(ctx, duration)
+		return
+	}
+
+	d, err := parseMuteDuration(duration)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	// The current time isn't used as a default, as functions must return the
+	// same result during the plan and the apply
+	start, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, "from: invalid RFC 3339 time "+from)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, start.Add(d).UTC().Format(time.RFC3339))
+}
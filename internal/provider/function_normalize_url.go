@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// normalizeURLFunction exposes the normalization of check URLs, so that
+// modules can compare them with the ones of existing checks.
+type normalizeURLFunction struct{}
+
+var _ function.Function = &normalizeURLFunction{}
+
+func newNormalizeURLFunction() function.Function {
+	return &normalizeURLFunction{}
+}
+
+func (f *normalizeURLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_url"
+}
+
+func (f *normalizeURLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Normalizes a check URL",
+		MarkdownDescription: "Normalizes a check URL the way the provider compares them: the `icmp://` prefix the API adds to ICMP checks and the credentials kept in `basic_auth` are removed.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "url",
+				Description: "URL of the check.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *normalizeURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rawURL string
+	resp.Error = req.Arguments.Get(ctx, &rawURL)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, checkAdoptionURL(rawURL))
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// periodFunction converts a duration to the `period` of a check, rejecting
+// the intervals the API doesn't support.
+type periodFunction struct{}
+
+var _ function.Function = &periodFunction{}
+
+func newPeriodFunction() function.Function {
+	return &periodFunction{}
+}
+
+func (f *periodFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "period"
+}
+
+func (f *periodFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Converts a duration to a check period",
+		MarkdownDescription: "Converts a duration such as `5m` to the number of seconds expected by `period` (300). Fails for the intervals the API doesn't support, which are 15s, 30s, 1m, 2m, 5m, 10m, 30m and 1h.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "duration",
+				Description: "Interval between checks, e.g. 30s, 5m or 1h.",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *periodFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var duration string
+	resp.Error = req.Arguments.Get(ctx, &duration)
+	if resp.Error != nil {
+		return
+	}
+
+	period, err := checkPeriod(duration)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, int64(period))
+}
+
+// checkPeriod converts a duration to a period supported by the API.
+func checkPeriod(duration string) (int, error) {
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q, expected a value such as 30s, 5m or 1h", duration)
+	}
+
+	if seconds := int(d.Seconds()); d == time.Duration(seconds)*time.Second && slices.Contains(checkPeriods, seconds) {
+		return seconds, nil
+	}
+
+	return 0, fmt.Errorf("unsupported period %s, expected one of 15s, 30s, 1m, 2m, 5m, 10m, 30m or 1h", duration)
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// mergeIDs returns the union of both lists, keeping the order.
+func mergeIDs(ids, extra []string) []string {
+	seen := map[string]bool{}
+	merged := []string{}
+	for _, id := range append(append([]string{}, ids...), extra...) {
+		if !seen[id] {
+			seen[id] = true
+			merged = append(merged, id)
+		}
+	}
+	return merged
+}
+
+func setToStringSlice(set *schema.Set) []string {
+	var stringSlice []string
+	for _, s := range set.List() {
+		stringSlice = append(stringSlice, s.(string))
+	}
+	return stringSlice
+}
+
+func listToStringSlice(list []interface{}) []string {
+	var stringSlice []string
+	for _, s := range list {
+		stringSlice = append(stringSlice, s.(string))
+	}
+	return stringSlice
+}
+
+func sortedKeys(m map[int]string) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sergo-techhub/updown"
+)
+
+func TestMergeIDs(t *testing.T) {
+	merged := mergeIDs([]string{"email:2", "email:1"}, []string{"email:1", "slack:3"})
+	if expected := []string{"email:2", "email:1", "slack:3"}; !reflect.DeepEqual(merged, expected) {
+		t.Errorf("got %v, expected %v", merged, expected)
+	}
+}
+
+// newHandlerTestClient returns a client of an API served by the handler.
+func newHandlerTestClient(t *testing.T, handler http.HandlerFunc) *updown.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := updown.NewClient("test", nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/api/")
+	return client
+}
+
+// newTestClient returns a client of an API serving the responses by path.
+func newTestClient(t *testing.T, responses map[string]interface{}) *updown.Client {
+	return newHandlerTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[strings.TrimPrefix(r.URL.Path, "/api/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	})
+}
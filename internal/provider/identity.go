@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// idIdentity describes a resource identity made of the single attribute
+// holding the ID of the resource, which never changes once created.
+func idIdentity(attribute, description string) *schema.ResourceIdentity {
+	return &schema.ResourceIdentity{
+		Version: 0,
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				attribute: {
+					Type:              schema.TypeString,
+					RequiredForImport: true,
+					Description:       description,
+				},
+			}
+		},
+	}
+}
+
+// setIDIdentity stores the ID of the resource in its identity.
+func setIDIdentity(d *schema.ResourceData, attribute string) error {
+	identity, err := d.Identity()
+	if err != nil {
+		return err
+	}
+	return identity.Set(attribute, d.Id())
+}
+
+// importFromIdentity sets the ID of a resource imported by identity, which
+// holds the ID itself so there's nothing to resolve.
+func importFromIdentity(d *schema.ResourceData, attribute string) ([]*schema.ResourceData, error) {
+	identity, err := d.Identity()
+	if err != nil {
+		return nil, err
+	}
+
+	id, ok := identity.GetOk(attribute)
+	if !ok {
+		return nil, fmt.Errorf("expected identity to contain %s", attribute)
+	}
+
+	d.SetId(id.(string))
+	return []*schema.ResourceData{d}, nil
+}
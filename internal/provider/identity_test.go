@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sergo-techhub/updown"
+)
+
+func TestResourceIdentity(t *testing.T) {
+	client := newTestClient(t, map[string]interface{}{
+		"checks/abcd":  updown.Check{Token: "abcd", URL: "https://example.com", Type: "https", Alias: "renamed", Period: 300},
+		"status_pages": []updown.StatusPage{{Token: "efgh", Name: "renamed", Visibility: "public"}},
+		"recipients":   []updown.Recipient{{ID: "email:123456789", Type: "email", Value: "ops@example.com", Name: "ops@example.com"}},
+	})
+
+	for name, tc := range map[string]struct {
+		resource  *schema.Resource
+		attribute string
+		id        string
+	}{
+		"check":       {checkResource(), "token", "abcd"},
+		"status page": {statusPageResource(), "token", "efgh"},
+		"recipient":   {recipientResource(), "id", "email:123456789"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := tc.resource.Data(&terraform.InstanceState{ID: tc.id})
+
+			if diags := tc.resource.ReadContext(context.Background(), d, &providerMeta{client: client}); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			identity, err := d.Identity()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := identity.Get(tc.attribute); got != tc.id {
+				t.Errorf("got identity %v, expected %s", got, tc.id)
+			}
+		})
+	}
+}
+
+func TestResourceRead_notFound(t *testing.T) {
+	// The check isn't served, and the lists are empty
+	client := newTestClient(t, map[string]interface{}{
+		"status_pages": []updown.StatusPage{},
+		"recipients":   []updown.Recipient{},
+	})
+
+	for name, tc := range map[string]struct {
+		resource *schema.Resource
+		id       string
+	}{
+		"check":       {checkResource(), "abcd"},
+		"status page": {statusPageResource(), "efgh"},
+		"recipient":   {recipientResource(), "email:123456789"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := tc.resource.Data(&terraform.InstanceState{ID: tc.id})
+
+			if diags := tc.resource.ReadContext(context.Background(), d, &providerMeta{client: client}); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if d.Id() != "" {
+				t.Errorf("expected the resource to be removed from the state, got ID %s", d.Id())
+			}
+		})
+	}
+}
+
+func TestResourceIdentity_import(t *testing.T) {
+	for name, tc := range map[string]struct {
+		resource  *schema.Resource
+		attribute string
+		id        string
+	}{
+		"check":       {checkResource(), "token", "abcd"},
+		"status page": {statusPageResource(), "token", "efgh"},
+		"recipient":   {recipientResource(), "id", "email:123456789"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := tc.resource.Data(&terraform.InstanceState{Identity: map[string]string{tc.attribute: tc.id}})
+
+			// Resolved without any call to the API, hence the nil meta
+			imported, err := tc.resource.Importer.StateContext(context.Background(), d, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(imported) != 1 || imported[0].Id() != tc.id {
+				t.Errorf("expected the resource to be imported with ID %s, got %v", tc.id, imported)
+			}
+		})
+	}
+}
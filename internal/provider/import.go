@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sergo-techhub/updown"
+)
+
+// checkImport accepts `alias:<alias>` and `url:<url>` on top of the check
+// token and identity.
+func checkImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() == "" {
+		return importFromIdentity(d, "token")
+	}
+
+	if kind, _, _ := strings.Cut(d.Id(), ":"); kind != "alias" && kind != "url" {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	checks, err := listChecks(ctx, meta.(*providerMeta).client)
+	if err != nil {
+		return nil, fmt.Errorf("reading checks from the API: %w", err)
+	}
+
+	token, err := resolveCheckImportID(checks, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(token)
+	return []*schema.ResourceData{d}, nil
+}
+
+// resolveCheckImportID returns the token of the only check matching an
+// `alias:` or `url:` import ID.
+func resolveCheckImportID(checks []updown.Check, id string) (string, error) {
+	kind, value, _ := strings.Cut(id, ":")
+
+	var tokens []string
+	for _, check := range checks {
+		switch {
+		case kind == "alias" && check.Alias == value,
+			kind == "url" && checkAdoptionURL(check.URL) == checkAdoptionURL(value):
+			tokens = append(tokens, check.Token)
+		}
+	}
+
+	return singleImportMatch("check", id, tokens)
+}
+
+// recipientImport accepts `<type>:<value>` and `name:<name>` on top of the
+// recipient identity and ID, which already looks like `email:123456789`.
+func recipientImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() == "" {
+		return importFromIdentity(d, "id")
+	}
+
+	if !strings.Contains(d.Id(), ":") {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	recipients, err := listRecipients(ctx, meta.(*providerMeta).client)
+	if err != nil {
+		return nil, fmt.Errorf("reading recipients from the API: %w", err)
+	}
+
+	recipientID, err := resolveRecipientImportID(recipients, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(recipientID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// resolveRecipientImportID returns the ID of the only recipient matching a
+// `<type>:<value>` or `name:<name>` import ID, or the ID itself when it
+// belongs to a recipient.
+func resolveRecipientImportID(recipients []updown.Recipient, id string) (string, error) {
+	for _, r := range recipients {
+		if r.ID == id {
+			return id, nil
+		}
+	}
+
+	kind, value, _ := strings.Cut(id, ":")
+
+	var ids []string
+	for _, r := range recipients {
+		stored := r.Value
+		if stored == "" {
+			stored = r.Name
+		}
+
+		switch {
+		case kind == "name" && r.Name == value,
+			kind == string(r.Type) && normalizeRecipientValue(kind, stored) == normalizeRecipientValue(kind, value):
+			ids = append(ids, r.ID)
+		}
+	}
+
+	return singleImportMatch("recipient", id, ids)
+}
+
+// statusPageImport accepts `name:<name>` on top of the status page token and
+// identity.
+func statusPageImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() == "" {
+		return importFromIdentity(d, "token")
+	}
+
+	if !strings.HasPrefix(d.Id(), "name:") {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	statusPages, err := listStatusPages(ctx, meta.(*providerMeta).client)
+	if err != nil {
+		return nil, fmt.Errorf("reading status pages from the API: %w", err)
+	}
+
+	token, err := resolveStatusPageImportID(statusPages, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(token)
+	return []*schema.ResourceData{d}, nil
+}
+
+// resolveStatusPageImportID returns the token of the only status page matching
+// a `name:` import ID.
+func resolveStatusPageImportID(statusPages []updown.StatusPage, id string) (string, error) {
+	name := strings.TrimPrefix(id, "name:")
+
+	var tokens []string
+	for _, statusPage := range statusPages {
+		if statusPage.Name == name {
+			tokens = append(tokens, statusPage.Token)
+		}
+	}
+
+	return singleImportMatch("status page", id, tokens)
+}
+
+// singleImportMatch fails unless exactly one object matches the import ID.
+func singleImportMatch(kind, id string, matches []string) (string, error) {
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no %s matches import ID %q", kind, id)
+	case 1:
+		return matches[0], nil
+	}
+
+	return "", fmt.Errorf("import ID %q is ambiguous, it matches several %ss (%s), import one of them by ID instead", id, kind, strings.Join(matches, ", "))
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/sergo-techhub/updown"
+)
+
+func TestAccUpdownCheck_importByAlias(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	resourceName := "updown_check.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckUpdownCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUpdownCheckConfig_basic(rName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "alias:" + rName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResolveCheckImportID(t *testing.T) {
+	checks := []updown.Check{
+		{Token: "a", URL: "https://example.com/health", Alias: "checkout-api"},
+		{Token: "b", URL: "icmp://8.8.8.8", Alias: "dns"},
+		{Token: "c", URL: "https://example.org", Alias: "dup"},
+		{Token: "d", URL: "https://example.org", Alias: "dup"},
+	}
+
+	for id, expected := range map[string]string{
+		"alias:checkout-api":              "a",
+		"url:https://example.com/health":  "a",
+		"url:8.8.8.8":                     "b",
+		"alias:unknown":                   `no check matches import ID "alias:unknown"`,
+		"alias:dup":                       `import ID "alias:dup" is ambiguous, it matches several checks (c, d)`,
+		"url:https://example.org":         `is ambiguous`,
+		"url:https://example.com/missing": `no check matches`,
+	} {
+		token, err := resolveCheckImportID(checks, id)
+		switch {
+		case err != nil && !strings.Contains(err.Error(), expected):
+			t.Errorf("resolveCheckImportID(%q) failed with %q, expected %q", id, err, expected)
+		case err == nil && token != expected:
+			t.Errorf("resolveCheckImportID(%q) = %q, expected %q", id, token, expected)
+		}
+	}
+}
+
+func TestResolveRecipientImportID(t *testing.T) {
+	recipients := []updown.Recipient{
+		{ID: "email:123", Type: "email", Name: "ops@example.com"},
+		{ID: "sms:456", Type: "sms", Value: "+33612345678", Name: "On-call"},
+		{ID: "webhook:789", Type: "webhook", Value: "https://example.com/a", Name: "Hook"},
+		{ID: "webhook:790", Type: "webhook", Value: "https://example.com/b", Name: "Hook"},
+	}
+
+	for id, expected := range map[string]string{
+		"email:123":             "email:123",
+		"email:OPS@example.com": "email:123",
+		"sms:+33 6 12 34 56 78": "sms:456",
+		"name:On-call":          "sms:456",
+		"name:Hook":             `is ambiguous, it matches several recipients (webhook:789, webhook:790)`,
+		"email:dev@example.com": `no recipient matches import ID "email:dev@example.com"`,
+	} {
+		recipientID, err := resolveRecipientImportID(recipients, id)
+		switch {
+		case err != nil && !strings.Contains(err.Error(), expected):
+			t.Errorf("resolveRecipientImportID(%q) failed with %q, expected %q", id, err, expected)
+		case err == nil && recipientID != expected:
+			t.Errorf("resolveRecipientImportID(%q) = %q, expected %q", id, recipientID, expected)
+		}
+	}
+}
+
+func TestResolveStatusPageImportID(t *testing.T) {
+	statusPages := []updown.StatusPage{
+		{Token: "a", Name: "Public Status"},
+		{Token: "b", Name: "Internal"},
+		{Token: "c", Name: "Internal"},
+	}
+
+	if token, err := resolveStatusPageImportID(statusPages, "name:Public Status"); err != nil || token != "a" {
+		t.Errorf("unexpected result: %q, %v", token, err)
+	}
+
+	if _, err := resolveStatusPageImportID(statusPages, "name:Internal"); err == nil || !strings.Contains(err.Error(), "(b, c)") {
+		t.Errorf("expected an ambiguity error, got %v", err)
+	}
+
+	if _, err := resolveStatusPageImportID(statusPages, "name:Partners"); err == nil {
+		t.Error("expected an error")
+	}
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// sdkListResource implements what the list resources have in common, as they
+// list resources served by the SDK provider.
+type sdkListResource struct {
+	typeName string
+	resource func() *schema.Resource
+	meta     *providerMeta
+}
+
+func (r *sdkListResource) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.typeName
+}
+
+func (r *sdkListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Not configured yet when validating
+	if req.ProviderData == nil {
+		return
+	}
+
+	meta, ok := req.ProviderData.(*providerMeta)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *providerMeta, got %T. This is always a bug in the provider.", req.ProviderData))
+		return
+	}
+	r.meta = meta
+}
+
+// RawV5Schemas hands the schemas of the SDK resource over to the framework,
+// which doesn't know about it otherwise.
+func (r *sdkListResource) RawV5Schemas(ctx context.Context, _ list.RawV5SchemaRequest, resp *list.RawV5SchemaResponse) {
+	server := schema.NewGRPCProviderServer(New()())
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err == nil {
+		resp.ProtoV5Schema = schemas.ResourceSchemas[r.typeName]
+	}
+
+	identitySchemas, err := server.GetResourceIdentitySchemas(ctx, &tfprotov5.GetResourceIdentitySchemasRequest{})
+	if err == nil {
+		resp.ProtoV5IdentitySchema = identitySchemas.IdentitySchemas[r.typeName]
+	}
+}
+
+// result builds the list result of a resource whose data is set by the
+// setData callback, the same way its Read function would.
+func (r *sdkListResource) result(ctx context.Context, req list.ListRequest, id, displayName string, setData func(*schema.ResourceData) error) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = displayName
+
+	sdkResource := r.resource()
+	d := sdkResource.Data(&terraform.InstanceState{ID: id})
+	if err := setData(d); err != nil {
+		result.Diagnostics.AddError("Reading "+r.typeName+" "+id, err.Error())
+		return result
+	}
+
+	for attribute := range sdkResource.Identity.SchemaMap() {
+		result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root(attribute), id)...)
+	}
+
+	if req.IncludeResource {
+		raw, err := sdkResourceValue(sdkResource, d, result.Resource.Schema.Type().TerraformType(ctx))
+		if err != nil {
+			result.Diagnostics.AddError("Converting "+r.typeName+" "+id, err.Error())
+			return result
+		}
+		result.Resource.Raw = raw
+	}
+
+	return result
+}
+
+// sdkResourceValue converts the resource data of an SDK resource to the value
+// expected by the framework.
+func sdkResourceValue(r *schema.Resource, d *schema.ResourceData, ty tftypes.Type) (tftypes.Value, error) {
+	impliedType := r.CoreConfigSchema().ImpliedType()
+
+	value, err := d.State().AttrsAsObjectValue(impliedType)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	b, err := msgpack.Marshal(value, impliedType)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	return (&tfprotov5.DynamicValue{MsgPack: b}).Unmarshal(ty)
+}
+
+// limitReached tells whether Terraform doesn't expect more results.
+func limitReached(req list.ListRequest, results []list.ListResult) bool {
+	return req.Limit > 0 && int64(len(results)) >= req.Limit
+}
+
+// listError reports a failure of the whole listing.
+func listError(summary string, err error) iter.Seq[list.ListResult] {
+	var diags diag.Diagnostics
+	diags.AddError(summary, err.Error())
+	return list.ListResultsStreamDiagnostics(diags)
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sergo-techhub/updown"
+)
+
+// checkListResource lists the checks for `terraform query`, so that the ones
+// created by hand can be found and imported.
+type checkListResource struct {
+	sdkListResource
+}
+
+var (
+	_ list.ListResourceWithConfigure    = &checkListResource{}
+	_ list.ListResourceWithRawV5Schemas = &checkListResource{}
+)
+
+func newCheckListResource() list.ListResource {
+	return &checkListResource{sdkListResource{typeName: "updown_check", resource: checkResource}}
+}
+
+type checkListFilters struct {
+	Alias   types.String `tfsdk:"alias"`
+	Type    types.String `tfsdk:"type"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *checkListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the checks, optionally filtered.",
+		Attributes: map[string]listschema.Attribute{
+			"alias": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the checks with this alias.",
+			},
+			"type": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the checks of this type (http, https, icmp, tcp or tcps).",
+			},
+			"enabled": listschema.BoolAttribute{
+				Optional:    true,
+				Description: "Only list the enabled (true) or disabled (false) checks.",
+			},
+		},
+	}
+}
+
+// matches tells whether the check passes the filters, unset ones matching
+// every check.
+func (f checkListFilters) matches(check updown.Check) bool {
+	if !f.Alias.IsNull() && f.Alias.ValueString() != check.Alias {
+		return false
+	}
+
+	if !f.Type.IsNull() && f.Type.ValueString() != check.Type {
+		return false
+	}
+
+	if !f.Enabled.IsNull() && f.Enabled.ValueBool() != check.Enabled {
+		return false
+	}
+
+	return true
+}
+
+func (r *checkListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filters checkListFilters
+	if diags := req.Config.Get(ctx, &filters); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	checks, err := listChecks(ctx, r.meta.client)
+	if err != nil {
+		stream.Results = listError("reading checks from the API", err)
+		return
+	}
+
+	var results []list.ListResult
+	for _, check := range checks {
+		if limitReached(req, results) {
+			break
+		}
+
+		if !filters.matches(check) {
+			continue
+		}
+
+		displayName := check.Alias
+		if displayName == "" {
+			displayName = checkAdoptionURL(check.URL)
+		}
+
+		results = append(results, r.result(ctx, req, check.Token, displayName, func(d *schema.ResourceData) error {
+			return setCheckData(ctx, d, r.meta, check)
+		}))
+	}
+
+	stream.Results = slices.Values(results)
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sergo-techhub/updown"
+)
+
+// recipientListResource lists the recipients for `terraform query`.
+type recipientListResource struct {
+	sdkListResource
+}
+
+var (
+	_ list.ListResourceWithConfigure    = &recipientListResource{}
+	_ list.ListResourceWithRawV5Schemas = &recipientListResource{}
+)
+
+func newRecipientListResource() list.ListResource {
+	return &recipientListResource{sdkListResource{typeName: "updown_recipient", resource: recipientResource}}
+}
+
+type recipientListFilters struct {
+	Type types.String `tfsdk:"type"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (r *recipientListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the recipients, optionally filtered.",
+		Attributes: map[string]listschema.Attribute{
+			"type": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the recipients of this type, including the ones set up through the web UI (slack, telegram, etc.).",
+			},
+			"name": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the recipients with this name.",
+			},
+		},
+	}
+}
+
+// matches tells whether the recipient passes the filters, unset ones matching
+// every recipient.
+func (f recipientListFilters) matches(r updown.Recipient) bool {
+	return recipientSelector{Type: f.Type.ValueString(), Name: f.Name.ValueString()}.matches(r)
+}
+
+func (r *recipientListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filters recipientListFilters
+	if diags := req.Config.Get(ctx, &filters); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	recipients, err := listRecipients(ctx, r.meta.client)
+	if err != nil {
+		stream.Results = listError("reading recipients from the API", err)
+		return
+	}
+
+	var results []list.ListResult
+	for _, recipient := range recipients {
+		if limitReached(req, results) {
+			break
+		}
+
+		if !filters.matches(recipient) {
+			continue
+		}
+
+		results = append(results, r.result(ctx, req, recipient.ID, recipient.Name, func(d *schema.ResourceData) error {
+			return setRecipientData(d, recipient)
+		}))
+	}
+
+	stream.Results = slices.Values(results)
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sergo-techhub/updown"
+)
+
+// statusPageListResource lists the status pages for `terraform query`.
+type statusPageListResource struct {
+	sdkListResource
+}
+
+var (
+	_ list.ListResourceWithConfigure    = &statusPageListResource{}
+	_ list.ListResourceWithRawV5Schemas = &statusPageListResource{}
+)
+
+func newStatusPageListResource() list.ListResource {
+	return &statusPageListResource{sdkListResource{typeName: "updown_status_page", resource: statusPageResource}}
+}
+
+type statusPageListFilters struct {
+	Name       types.String `tfsdk:"name"`
+	Visibility types.String `tfsdk:"visibility"`
+}
+
+func (r *statusPageListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the status pages, optionally filtered.",
+		Attributes: map[string]listschema.Attribute{
+			"name": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the status pages with this name.",
+			},
+			"visibility": listschema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the status pages with this visibility (public, protected or private).",
+			},
+		},
+	}
+}
+
+// matches tells whether the status page passes the filters, unset ones
+// matching every status page.
+func (f statusPageListFilters) matches(statusPage updown.StatusPage) bool {
+	if !f.Name.IsNull() && f.Name.ValueString() != statusPage.Name {
+		return false
+	}
+
+	if !f.Visibility.IsNull() && f.Visibility.ValueString() != statusPage.Visibility {
+		return false
+	}
+
+	return true
+}
+
+func (r *statusPageListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var filters statusPageListFilters
+	if diags := req.Config.Get(ctx, &filters); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	statusPages, err := listStatusPages(ctx, r.meta.client)
+	if err != nil {
+		stream.Results = listError("reading status pages from the API", err)
+		return
+	}
+
+	var results []list.ListResult
+	for _, statusPage := range statusPages {
+		if limitReached(req, results) {
+			break
+		}
+
+		if !filters.matches(statusPage) {
+			continue
+		}
+
+		results = append(results, r.result(ctx, req, statusPage.Token, statusPage.Name, func(d *schema.ResourceData) error {
+			return setStatusPageData(ctx, d, r.meta, statusPage)
+		}))
+	}
+
+	stream.Results = slices.Values(results)
+}
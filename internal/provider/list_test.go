@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sergo-techhub/updown"
+)
+
+func TestCheckListFilters(t *testing.T) {
+	check := updown.Check{Alias: "checkout-api", Type: "https", Enabled: true}
+
+	for name, tc := range map[string]struct {
+		filters  checkListFilters
+		expected bool
+	}{
+		"no filter": {
+			filters:  checkListFilters{types.StringNull(), types.StringNull(), types.BoolNull()},
+			expected: true,
+		},
+		"matching": {
+			filters:  checkListFilters{types.StringValue("checkout-api"), types.StringValue("https"), types.BoolValue(true)},
+			expected: true,
+		},
+		"other alias": {
+			filters: checkListFilters{types.StringValue("website"), types.StringNull(), types.BoolNull()},
+		},
+		"other type": {
+			filters: checkListFilters{types.StringNull(), types.StringValue("icmp"), types.BoolNull()},
+		},
+		"disabled only": {
+			filters: checkListFilters{types.StringNull(), types.StringNull(), types.BoolValue(false)},
+		},
+	} {
+		if got := tc.filters.matches(check); got != tc.expected {
+			t.Errorf("%s: got %t, expected %t", name, got, tc.expected)
+		}
+	}
+}
+
+func TestStatusPageListFilters(t *testing.T) {
+	statusPage := updown.StatusPage{Name: "Public Status", Visibility: "public"}
+
+	if !(statusPageListFilters{types.StringNull(), types.StringValue("public")}).matches(statusPage) {
+		t.Error("expected the status page to match")
+	}
+
+	if (statusPageListFilters{types.StringValue("Internal"), types.StringNull()}).matches(statusPage) {
+		t.Error("expected the status page not to match")
+	}
+}
+
+func TestSDKResourceValue(t *testing.T) {
+	ctx := context.Background()
+
+	schemas, err := schema.NewGRPCProviderServer(New()()).GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	ty := schemas.ResourceSchemas["updown_check"].ValueType()
+
+	d := checkResource().Data(&terraform.InstanceState{ID: "abcd"})
+	check := updown.Check{Token: "abcd", URL: "https://example.com", Alias: "website", Type: "https", Period: 60, Enabled: true}
+	if err := setCheckData(context.Background(), d, &providerMeta{}, check); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	value, err := sdkResourceValue(checkResource(), d, ty)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for name, expected := range map[string]string{"id": "abcd", "url": "https://example.com", "alias": "website"} {
+		var got string
+		if err := attributes[name].As(&got); err != nil || got != expected {
+			t.Errorf("got %s = %q (%v), expected %q", name, got, err, expected)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package provider
+
+import "sync"
+
+// mutexKV hands out a mutex per key, serializing the read-modify-write
+// operations that several resources can perform on the same remote object.
+type mutexKV struct {
+	lock  sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+// resourceLocks is shared by every resource of the provider.
+var resourceLocks = newMutexKV()
+
+func newMutexKV() *mutexKV {
+	return &mutexKV{
+		store: make(map[string]*sync.Mutex),
+	}
+}
+
+// Lock locks the mutex for the given key, creating it if needed.
+func (m *mutexKV) Lock(key string) {
+	m.get(key).Lock()
+}
+
+// Unlock unlocks the mutex for the given key.
+func (m *mutexKV) Unlock(key string) {
+	m.get(key).Unlock()
+}
+
+func (m *mutexKV) get(key string) *sync.Mutex {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+	return mutex
+}
+
+func checkLockKey(token string) string {
+	return "check/" + token
+}
+
+func statusPageLockKey(token string) string {
+	return "status_page/" + token
+}
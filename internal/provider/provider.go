@@ -16,25 +16,61 @@ func New() func() *schema.Provider {
 					DefaultFunc: schema.EnvDefaultFunc("UPDOWN_API_KEY", ""),
 					Description: "API key to use in order to authenticated against updown.io API.",
 				},
+				"allow_private_targets": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Allow checks targeting addresses updown.io nodes cannot reach (loopback, private ranges, `.internal` hostnames, etc.). Set to false to reject them when planning.",
+				},
+				"adopt_existing": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Take ownership of existing checks (same URL and alias), recipients (same type and value) and status pages (same name) on create instead of creating duplicates. Can be overridden with the `adopt_existing` argument of each resource.",
+				},
 			},
 
 			ConfigureFunc: providerConfigure,
 
 			DataSourcesMap: map[string]*schema.Resource{
-				"updown_nodes": nodesDataSource(),
+				"updown_check_status": checkStatusDataSource(),
+				"updown_nodes":        nodesDataSource(),
 			},
 
 			ResourcesMap: map[string]*schema.Resource{
-				"updown_check":       checkResource(),
-				"updown_recipient":   recipientResource(),
-				"updown_status_page": statusPageResource(),
+				"updown_check":             checkResource(),
+				"updown_check_recipient":   checkRecipientResource(),
+				"updown_recipient":         recipientResource(),
+				"updown_status_page":       statusPageResource(),
+				"updown_status_page_check": statusPageCheckResource(),
 			},
 		}
 	}
 }
 
+// providerMeta is handed over to resources and data sources as their meta
+type providerMeta struct {
+	client              *updown.Client
+	allowPrivateTargets bool
+	adoptExisting       bool
+}
+
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	client := updown.NewClient(d.Get("api_key").(string), nil)
+	return newProviderMeta(
+		d.Get("api_key").(string),
+		d.Get("allow_private_targets").(bool),
+		d.Get("adopt_existing").(bool),
+	), nil
+}
+
+// newProviderMeta is shared by the SDK and framework providers, which are
+// configured with the same arguments.
+func newProviderMeta(apiKey string, allowPrivateTargets, adoptExisting bool) *providerMeta {
+	client := updown.NewClient(apiKey, nil)
 	client.SkipCache = true
-	return client, nil
+	return &providerMeta{
+		client:              client,
+		allowPrivateTargets: allowPrivateTargets,
+		adoptExisting:       adoptExisting,
+	}
 }
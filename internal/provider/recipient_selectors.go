@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sergo-techhub/updown"
+)
+
+// recipientSelector matches recipients by type and/or name, which is the only
+// way to reference the ones created through the web UI.
+type recipientSelector struct {
+	Type string
+	Name string
+}
+
+func expandRecipientSelectors(v []interface{}) []recipientSelector {
+	selectors := make([]recipientSelector, 0, len(v))
+	for _, s := range v {
+		m, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		selectors = append(selectors, recipientSelector{
+			Type: m["type"].(string),
+			Name: m["name"].(string),
+		})
+	}
+	return selectors
+}
+
+func (s recipientSelector) matches(r updown.Recipient) bool {
+	if s.Type != "" && s.Type != string(r.Type) {
+		return false
+	}
+
+	if s.Name != "" && s.Name != r.Name {
+		return false
+	}
+
+	return true
+}
+
+func (s recipientSelector) String() string {
+	var parts []string
+	if s.Type != "" {
+		parts = append(parts, fmt.Sprintf("type = %q", s.Type))
+	}
+	if s.Name != "" {
+		parts = append(parts, fmt.Sprintf("name = %q", s.Name))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// resolveRecipientSelectors returns the sorted IDs of the recipients matched
+// by the selectors, along with an error for every selector matching none.
+func resolveRecipientSelectors(recipients []updown.Recipient, selectors []recipientSelector) ([]string, error) {
+	var errs []error
+	matched := map[string]bool{}
+
+	for i, s := range selectors {
+		if s.Type == "" && s.Name == "" {
+			errs = append(errs, fmt.Errorf("recipient_selectors.%d: at least one of type or name must be set", i))
+			continue
+		}
+
+		found := false
+		for _, r := range recipients {
+			if s.matches(r) {
+				matched[r.ID], found = true, true
+			}
+		}
+
+		if !found {
+			errs = append(errs, fmt.Errorf("recipient_selectors.%d: no recipient matches %s", i, s))
+		}
+	}
+
+	ids := make([]string, 0, len(matched))
+	for id := range matched {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids, errors.Join(errs...)
+}
+
+// unknownRecipientIDs returns the IDs which don't belong to any recipient.
+func unknownRecipientIDs(recipients []updown.Recipient, ids []string) []string {
+	existing := map[string]bool{}
+	for _, r := range recipients {
+		existing[r.ID] = true
+	}
+
+	var unknown []string
+	for _, id := range ids {
+		if !existing[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	return unknown
+}
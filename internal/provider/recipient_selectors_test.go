@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sergo-techhub/updown"
+)
+
+var testRecipients = []updown.Recipient{
+	{ID: "email:1", Type: updown.RecipientTypeEmail, Name: "ops@example.com"},
+	{ID: "email:2", Type: updown.RecipientTypeEmail, Name: "dev@example.com"},
+	{ID: "slack:3", Type: updown.RecipientTypeSlack, Name: "#alerts"},
+	{ID: "telegram:4", Type: updown.RecipientTypeTelegram, Name: "#alerts"},
+}
+
+func TestResolveRecipientSelectors(t *testing.T) {
+	ids, err := resolveRecipientSelectors(testRecipients, []recipientSelector{
+		{Type: "slack", Name: "#alerts"},
+		{Type: "email"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []string{"email:1", "email:2", "slack:3"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("got %v, expected %v", ids, expected)
+	}
+
+	ids, err = resolveRecipientSelectors(testRecipients, []recipientSelector{{Name: "#alerts"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []string{"slack:3", "telegram:4"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("got %v, expected %v", ids, expected)
+	}
+}
+
+func TestResolveRecipientSelectors_noMatch(t *testing.T) {
+	_, err := resolveRecipientSelectors(testRecipients, []recipientSelector{
+		{Type: "email"},
+		{Type: "sms", Name: "+33600000000"},
+		{},
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for _, expected := range []string{
+		`recipient_selectors.1: no recipient matches {type = "sms", name = "+33600000000"}`,
+		"recipient_selectors.2: at least one of type or name must be set",
+	} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q in error, got %q", expected, err)
+		}
+	}
+}
+
+func TestUnknownRecipientIDs(t *testing.T) {
+	unknown := unknownRecipientIDs(testRecipients, []string{"email:1", "email:9", "slack:3", "sms:5"})
+	if expected := []string{"email:9", "sms:5"}; !reflect.DeepEqual(unknown, expected) {
+		t.Errorf("got %v, expected %v", unknown, expected)
+	}
+}
@@ -1,9 +1,19 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/sergo-techhub/updown"
@@ -13,21 +23,45 @@ func checkResource() *schema.Resource {
 	return &schema.Resource{
 		Description: "`updown_check` defines a check",
 
-		Create: checkCreate,
-		Read:   checkRead,
-		Delete: checkDelete,
-		Update: checkUpdate,
-		Exists: checkExists,
+		CreateContext: withTimeout(schema.TimeoutCreate, "check", checkCreate),
+		ReadContext:   withTimeout(schema.TimeoutRead, "check", checkRead),
+		DeleteContext: withTimeout(schema.TimeoutDelete, "check", checkDelete),
+		UpdateContext: withTimeout(schema.TimeoutUpdate, "check", checkUpdate),
+
+		// Creating and updating a check can wait for wait_for_status
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.All(
+			checkCustomizeDiff,
+			checkRecipientsCustomizeDiff,
+			customdiff.ComputedIf("muted_until", func(_ context.Context, d *schema.ResourceDiff, _ interface{}) bool {
+				return d.HasChanges("mute_until", "mute_for")
+			}),
+		),
+
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			checkTypeValidateConfig,
+			checkWaitValidateConfig,
+			checkHTTPBodyValidateConfig,
+		},
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: checkImport,
 		},
 
+		Identity: idIdentity("token", "Token of the check."),
+
 		Schema: map[string]*schema.Schema{
 			"url": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The URL you want to monitor.",
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The URL you want to monitor.",
+				ValidateDiagFunc: validateCheckURL,
 			},
 			"period": {
 				Type:        schema.TypeInt,
@@ -64,9 +98,23 @@ func checkResource() *schema.Resource {
 				Description: "Search for this string in the page.",
 			},
 			"mute_until": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Mute notifications until given time, accepts a time, 'recovery', 'forever' or a duration relative to the time of apply such as '+45m'. Relative durations are kept as is in the state, see `muted_until` for the actual time, which also reports mutes applied outside of Terraform while this is unset.",
+				ValidateFunc:  validateMuteUntil,
+				ConflictsWith: []string{"mute_for"},
+			},
+			"mute_for": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Mute notifications for this duration (e.g. 45m or 2h) from the time of apply, the same as a relative `mute_until`.",
+				ValidateFunc:  validateMuteDuration,
+				ConflictsWith: []string{"mute_until"},
+			},
+			"muted_until": {
 				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Mute notifications until given time, accepts a time, 'recovery' or 'forever'.",
+				Computed:    true,
+				Description: "Time until which notifications are muted as returned by the API, 'recovery' or 'forever'. Empty when the check isn't muted.",
 			},
 			"disabled_locations": {
 				Type:        schema.TypeSet,
@@ -80,7 +128,43 @@ func checkResource() *schema.Resource {
 				Type:        schema.TypeSet,
 				Optional:    true,
 				Computed:    true,
-				Description: "Selected alert recipients. It's an array of recipient IDs you can get from the recipients API.",
+				Description: "Selected alert recipients. It's an array of recipient IDs you can get from the recipients API. Set to `[]` to notify nobody.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"recipients_mode": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "authoritative",
+				Description: "How `recipients` is reconciled: `authoritative` attaches exactly the configured recipients, `additive` only makes sure they are attached and leaves the ones added elsewhere (e.g. the web UI) alone.",
+				ValidateFunc: validation.StringInSlice([]string{
+					"authoritative", "additive",
+				}, false),
+			},
+			"recipient_selectors": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Select alert recipients by type and/or name instead of ID, e.g. the ones set up in the web UI. Matching recipients are notified on top of `recipients`, the selectors being evaluated again on every plan.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Type of the recipients to select (email, sms, webhook, slack_compatible, slack, telegram, etc.).",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Name of the recipients to select, as displayed in the web UI.",
+						},
+					},
+				},
+			},
+			"selected_recipients": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Recipient IDs matched by `recipient_selectors`.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -123,6 +207,34 @@ func checkResource() *schema.Resource {
 				Optional:    true,
 				Description: "Request body for POST/PUT/PATCH requests. Only for http/https checks.",
 			},
+			"wait_for_status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Wait after creating the check, or updating how it's checked (e.g. `url` or `period`), for a new result, which must be up with `up`, or can be anything with `any`. Fails once the create or update timeout is reached.",
+				ValidateFunc: validation.StringInSlice(checkStatuses, false),
+			},
+			"adopt_existing": adoptExistingSchema("URL and alias"),
+			"basic_auth": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "HTTP basic authentication credentials, kept out of `url`. Only for http/https checks.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Username sent to the monitored URL.",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Sensitive:   true,
+							Description: "Password sent to the monitored URL.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -134,6 +246,11 @@ func constructCheckPayload(d *schema.ResourceData) updown.CheckItem {
 		payload.URL = v.(string)
 	}
 
+	if v, ok := d.GetOk("basic_auth"); ok {
+		auth := v.([]interface{})[0].(map[string]interface{})
+		payload.URL = checkURLWithBasicAuth(payload.URL, auth["username"].(string), auth["password"].(string))
+	}
+
 	if v, ok := d.GetOk("period"); ok {
 		payload.Period = v.(int)
 	}
@@ -158,9 +275,7 @@ func constructCheckPayload(d *schema.ResourceData) updown.CheckItem {
 		payload.StringMatch = v.(string)
 	}
 
-	if v, ok := d.GetOk("mute_until"); ok {
-		payload.MuteUntil = v.(string)
-	}
+	payload.MuteUntil = checkMuteUntil(d, time.Now())
 
 	if v, ok := d.GetOk("disabled_locations"); ok {
 		interfaceSlice := v.(*schema.Set).List()
@@ -187,7 +302,7 @@ func constructCheckPayload(d *schema.ResourceData) updown.CheckItem {
 		}
 	}
 
-	// Get type for isHttpCheck logic
+	// Get type for the HTTP specific attributes
 	checkType := d.Get("type").(string)
 
 	// Only send type on CREATE (no ID yet), not on UPDATE
@@ -197,8 +312,7 @@ func constructCheckPayload(d *schema.ResourceData) updown.CheckItem {
 	}
 
 	// Only set http_verb and http_body for HTTP/HTTPS checks
-	isHttpCheck := checkType == "" || checkType == "http" || checkType == "https"
-	if isHttpCheck {
+	if isHTTPCheckType(checkType) {
 		httpVerb := d.Get("http_verb").(string)
 		if httpVerb != "" {
 			payload.HttpVerb = httpVerb
@@ -212,40 +326,182 @@ func constructCheckPayload(d *schema.ResourceData) updown.CheckItem {
 	return payload
 }
 
-func checkCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*updown.Client)
+// addSelectedRecipients merges the recipients matched by recipient_selectors
+// into the payload.
+func addSelectedRecipients(ctx context.Context, client *updown.Client, d *schema.ResourceData, payload *updown.CheckItem) error {
+	selectors := expandRecipientSelectors(d.Get("recipient_selectors").([]interface{}))
+	if len(selectors) == 0 {
+		return nil
+	}
+
+	recipients, err := listRecipients(ctx, client)
+	if err != nil {
+		return fmt.Errorf("reading recipients from the API: %w", err)
+	}
 
-	check, _, err := client.Check.Add(constructCheckPayload(d))
+	ids, err := resolveRecipientSelectors(recipients, selectors)
 	if err != nil {
-		return fmt.Errorf("creating check with the API: %w", err)
+		return err
+	}
+
+	payload.RecipientIDs = mergeIDs(payload.RecipientIDs, ids)
+	return nil
+}
+
+func checkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	if shouldAdoptExisting(d, meta) {
+		checks, err := listChecks(ctx, client)
+		if err != nil {
+			return diag.Errorf("reading checks from the API: %s", err)
+		}
+
+		check, found, err := findAdoptableCheck(checks, d.Get("url").(string), d.Get("alias").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if found {
+			d.SetId(check.Token)
+			if diags := checkUpdate(ctx, d, meta); diags.HasError() {
+				return diags
+			}
+			return adoptedWarning("check", check.Token, checkAdoptionURL(check.URL))
+		}
+	}
+
+	payload := constructCheckPayload(d)
+	if err := addSelectedRecipients(ctx, client, d, &payload); err != nil {
+		return diag.FromErr(err)
+	}
+
+	check, err := addCheck(ctx, client, withRecipients(d, payload, nil))
+	if err != nil {
+		return diag.Errorf("creating check with the API: %s", err)
 	}
 
 	d.SetId(check.Token)
 
-	return checkRead(d, meta)
+	if err := waitForCheck(ctx, d, meta, check.LastCheckAt); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return checkRead(ctx, d, meta)
+}
+
+// waitForCheck waits for the status configured by wait_for_status, with a
+// result more recent than lastCheckAt.
+func waitForCheck(ctx context.Context, d *schema.ResourceData, meta interface{}, lastCheckAt string) error {
+	status := d.Get("wait_for_status").(string)
+	if status == "" {
+		return nil
+	}
+
+	_, err := waitForCheckStatus(ctx, meta.(*providerMeta).client, d.Id(), status, lastCheckAt)
+	return err
 }
 
-func checkRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*updown.Client)
-	check, _, err := client.Check.Get(d.Id())
+func checkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	check, err := getCheck(ctx, client, d.Id())
 
+	// Deleted outside of Terraform, it's planned to be created again
+	if isNotFound(err) {
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
-		return fmt.Errorf("reading check from the API: %w", err)
+		return diag.Errorf("reading check from the API: %s", err)
 	}
 
+	return diag.FromErr(setCheckData(ctx, d, meta, check))
+}
+
+// setCheckData stores the check returned by the API in the resource data.
+func setCheckData(ctx context.Context, d *schema.ResourceData, meta interface{}, check updown.Check) error {
+	client := meta.(*providerMeta).client
+
 	// Normalize URL by stripping protocol prefix for non-HTTP checks
 	// The API returns URLs like "icmp://192.168.1.1" but we store just "192.168.1.1"
 	normalizedURL := check.URL
-	isHttpCheck := check.Type == "" || check.Type == "http" || check.Type == "https"
 
 	if check.Type == "icmp" {
 		normalizedURL = strings.TrimPrefix(normalizedURL, "icmp://")
 	}
 
+	// Credentials are embedded in the URL by the API, they're moved into
+	// basic_auth so they don't leak into `url`, including on import, unless
+	// they're configured in `url` itself
+	var basicAuth []interface{}
+	if current, err := url.Parse(d.Get("url").(string)); err != nil || current.User == nil {
+		var username, password string
+		normalizedURL, username, password = stripCheckURLCredentials(normalizedURL)
+		if username != "" {
+			basicAuth = []interface{}{map[string]interface{}{
+				"username": username,
+				"password": password,
+			}}
+		}
+	}
+
+	// Recipients only attached through recipient_selectors are kept out of
+	// `recipients`, so they don't show up as a drift of that attribute
+	recipientIDs := check.RecipientIDs
+	var selectedRecipients []string
+	if selectors := expandRecipientSelectors(d.Get("recipient_selectors").([]interface{})); len(selectors) > 0 {
+		recipients, err := listRecipients(ctx, client)
+		if err != nil {
+			return fmt.Errorf("reading recipients from the API: %w", err)
+		}
+
+		// Selectors not matching anything anymore are reported at plan time
+		selectedRecipients, _ = resolveRecipientSelectors(recipients, selectors)
+
+		configured := d.Get("recipients").(*schema.Set)
+		selected := map[string]bool{}
+		for _, id := range selectedRecipients {
+			selected[id] = !configured.Contains(id)
+		}
+
+		recipientIDs = nil
+		for _, id := range check.RecipientIDs {
+			if !selected[id] {
+				recipientIDs = append(recipientIDs, id)
+			}
+		}
+	}
+
+	// Only the recipients managed by Terraform are tracked in additive mode
+	recipientsMode := d.Get("recipients_mode").(string)
+	if recipientsMode == "" {
+		recipientsMode = "authoritative" // Not set on import
+	}
+	if recipientsMode == "additive" {
+		managed := d.Get("recipients").(*schema.Set)
+		ids := recipientIDs
+		recipientIDs = nil
+		for _, id := range ids {
+			if managed.Contains(id) {
+				recipientIDs = append(recipientIDs, id)
+			}
+		}
+	}
+
+	// Relative durations are resolved at apply time, the configured expression
+	// is kept so that the plan stays stable, even after the mute expired. A
+	// mute which isn't configured, e.g. by a maintenance window or an action,
+	// is only reported by muted_until, the next apply unmuting the check
+	// otherwise
+	muteUntil := check.MuteUntil
+	if muteRelative(d) || d.Get("mute_until").(string) == "" {
+		muteUntil = d.Get("mute_until").(string)
+	}
+
 	// Normalize http_verb
 	httpVerb := check.HttpVerb
 	httpBody := check.HttpBody
-	if !isHttpCheck {
+	if !isHTTPCheckType(check.Type) {
 		httpVerb = "GET/HEAD" // Match schema default for non-HTTP checks
 		httpBody = ""
 	} else if httpVerb == "GET" {
@@ -253,56 +509,468 @@ func checkRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	for k, v := range map[string]interface{}{
-		"url":                normalizedURL,
-		"period":             check.Period,
-		"apdex_t":            check.Apdex,
-		"enabled":            check.Enabled,
-		"published":          check.Published,
-		"alias":              check.Alias,
-		"string_match":       check.StringMatch,
-		"mute_until":         check.MuteUntil,
-		"disabled_locations": check.DisabledLocations,
-		"recipients":         check.RecipientIDs,
-		"custom_headers":     check.CustomHeaders,
-		"type":               check.Type,
-		"http_verb":          httpVerb,
-		"http_body":          httpBody,
+		"url":                 normalizedURL,
+		"period":              check.Period,
+		"apdex_t":             check.Apdex,
+		"enabled":             check.Enabled,
+		"published":           check.Published,
+		"alias":               check.Alias,
+		"string_match":        check.StringMatch,
+		"mute_until":          muteUntil,
+		"muted_until":         check.MuteUntil,
+		"disabled_locations":  check.DisabledLocations,
+		"recipients":          recipientIDs,
+		"recipients_mode":     recipientsMode,
+		"selected_recipients": selectedRecipients,
+		"custom_headers":      check.CustomHeaders,
+		"type":                check.Type,
+		"http_verb":           httpVerb,
+		"http_body":           httpBody,
+		"basic_auth":          basicAuth,
 	} {
 		if err := d.Set(k, v); err != nil {
 			return err
 		}
 	}
 
+	return setIDIdentity(d, "token")
+}
+
+// checkCustomizeDiff rejects non-routable targets unless the provider allows
+// them, which the validation of the configuration can't tell.
+func checkCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("url") || meta.(*providerMeta).allowPrivateTargets {
+		return nil
+	}
+
+	if reason := nonRoutableReason(d.Get("url").(string)); reason != "" {
+		return fmt.Errorf("url: points at %s which updown.io nodes cannot reach, set allow_private_targets in the provider to allow it", reason)
+	}
 	return nil
 }
 
-func checkUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*updown.Client)
+// configCheckType returns the type of check of the raw configuration,
+// inferred from url when not set, and false while it's unknown.
+func configCheckType(config cty.Value) (string, bool) {
+	if typ := config.GetAttr("type"); !typ.IsKnown() {
+		return "", false
+	} else if !typ.IsNull() && typ.AsString() != "" {
+		return typ.AsString(), true
+	}
+
+	rawURL := config.GetAttr("url")
+	if !rawURL.IsKnown() || rawURL.IsNull() {
+		return "", false
+	}
+	return inferCheckType(rawURL.AsString()), true
+}
+
+// configHTTPVerb returns the http_verb of the raw configuration, and false
+// while it's unknown.
+func configHTTPVerb(config cty.Value) (string, bool) {
+	verb := config.GetAttr("http_verb")
+	if !verb.IsKnown() {
+		return "", false
+	}
+	if verb.IsNull() {
+		return "GET/HEAD", true
+	}
+	return verb.AsString(), true
+}
+
+// configAttributeSet tells whether the attribute of the raw configuration is
+// known to be set to a non-empty value.
+func configAttributeSet(config cty.Value, name string) bool {
+	v := config.GetAttr(name)
+	switch {
+	case !v.IsKnown() || v.IsNull():
+		return false
+	case v.Type().Equals(cty.String):
+		return v.AsString() != ""
+	case v.Type().IsCollectionType():
+		return v.LengthInt() > 0
+	}
+	return true
+}
+
+// checkTypeValidateConfig rejects the attributes that the API would otherwise
+// silently drop for the type of check, and the URLs it can't check.
+func checkTypeValidateConfig(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	config := req.RawConfig
+	if config.IsNull() || !config.IsKnown() {
+		return
+	}
+
+	checkType, ok := configCheckType(config)
+	if !ok || isHTTPCheckType(checkType) {
+		return
+	}
+
+	for _, k := range []string{"string_match", "custom_headers", "http_body", "basic_auth"} {
+		if configAttributeSet(config, k) {
+			resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("%s is not supported by %s checks", k, checkType),
+				Detail:        fmt.Sprintf("The API ignores %s for %s checks, only http and https checks support it.", k, checkType),
+				AttributePath: cty.GetAttrPath(k),
+			})
+		}
+	}
+
+	if httpVerb, ok := configHTTPVerb(config); ok && httpVerb != "GET" && httpVerb != "GET/HEAD" {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("http_verb is not supported by %s checks", checkType),
+			Detail:        fmt.Sprintf("The API ignores http_verb for %s checks, only http and https checks support it.", checkType),
+			AttributePath: cty.GetAttrPath("http_verb"),
+		})
+	}
+
+	rawURL := config.GetAttr("url")
+	if !rawURL.IsKnown() || rawURL.IsNull() {
+		return
+	}
+	target := strings.TrimPrefix(rawURL.AsString(), checkType+"://")
+
+	var detail string
+	switch checkType {
+	case "tcp", "tcps":
+		if host, port, err := net.SplitHostPort(target); err != nil || host == "" || port == "" {
+			detail = fmt.Sprintf("%s checks require a host and a port, e.g. %s://example.com:443.", checkType, checkType)
+		}
+	case "icmp":
+		if !isHostnameOrIP(target) {
+			detail = fmt.Sprintf("icmp checks require a hostname or an IP address, got %q.", rawURL.AsString())
+		}
+	}
+	if detail != "" {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid %s check URL", checkType),
+			Detail:        detail,
+			AttributePath: cty.GetAttrPath("url"),
+		})
+	}
+}
+
+// checkWaitValidateConfig rejects waiting for the result of a disabled check,
+// which never comes.
+func checkWaitValidateConfig(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	config := req.RawConfig
+	if config.IsNull() || !config.IsKnown() || !configAttributeSet(config, "wait_for_status") {
+		return
+	}
+
+	if enabled := config.GetAttr("enabled"); enabled.IsKnown() && !enabled.IsNull() && enabled.False() {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "wait_for_status set on a disabled check",
+			Detail:        "Disabled checks have no result to wait for, remove wait_for_status or enable the check.",
+			AttributePath: cty.GetAttrPath("wait_for_status"),
+		})
+	}
+}
+
+// checkHTTPBodyValidateConfig warns when http_body is set on an HTTP check
+// using a GET verb, as the API doesn't send it. It's only a warning since
+// such configurations used to be accepted silently.
+func checkHTTPBodyValidateConfig(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	config := req.RawConfig
+	if config.IsNull() || !config.IsKnown() || !configAttributeSet(config, "http_body") {
+		return
+	}
+
+	httpVerb, ok := configHTTPVerb(config)
+	if !ok || (httpVerb != "GET" && httpVerb != "GET/HEAD") {
+		return
+	}
+
+	if checkType, ok := configCheckType(config); !ok || !isHTTPCheckType(checkType) {
+		return
+	}
+
+	resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+		Severity:      diag.Warning,
+		Summary:       fmt.Sprintf("http_body is not sent with %s requests", httpVerb),
+		Detail:        "The API ignores the body of GET requests, set http_verb to POST, PUT or PATCH to send it.",
+		AttributePath: cty.GetAttrPath("http_body"),
+	})
+}
+
+// muteRelative tells whether the mute is configured relatively to the time of
+// apply, with mute_for or a mute_until such as +45m.
+func muteRelative(d *schema.ResourceData) bool {
+	return d.Get("mute_for").(string) != "" || strings.HasPrefix(d.Get("mute_until").(string), "+")
+}
+
+// checkMuteUntil returns the mute_until sent to the API, relative durations
+// being resolved against now. They are only sent when they change, so that
+// updating other arguments doesn't mute the check again.
+func checkMuteUntil(d *schema.ResourceData, now time.Time) string {
+	if !muteRelative(d) {
+		return d.Get("mute_until").(string)
+	}
+
+	if d.Id() != "" && !d.HasChanges("mute_until", "mute_for") {
+		return ""
+	}
+
+	duration := d.Get("mute_for").(string)
+	if duration == "" {
+		duration = d.Get("mute_until").(string)
+	}
+
+	// Validated at plan time
+	muteFor, err := parseMuteDuration(duration)
+	if err != nil {
+		return ""
+	}
+	return now.Add(muteFor).UTC().Format(time.RFC3339)
+}
+
+// checkUnmuted tells whether mute_until and mute_for were both removed from
+// the configuration.
+func checkUnmuted(d *schema.ResourceData) bool {
+	return d.HasChanges("mute_until", "mute_for") && d.Get("mute_until").(string) == "" && d.Get("mute_for").(string) == ""
+}
+
+// withRecipients decides which recipients are sent to the API depending on
+// recipients_mode, current being the ones attached to the check.
+func withRecipients(d *schema.ResourceData, item updown.CheckItem, current []string) checkPayload {
+	payload := checkPayload{CheckItem: item}
+	ids := append([]string{}, item.RecipientIDs...)
+
+	if d.Get("recipients_mode").(string) == "additive" {
+		if d.Id() != "" {
+			old, _ := d.GetChange("recipients")
+			ids = additiveRecipientIDs(current, setToStringSlice(old.(*schema.Set)), ids)
+			payload.RecipientIDs = &ids
+		} else if len(ids) > 0 {
+			payload.RecipientIDs = &ids
+		}
+		return payload
+	}
+
+	if len(ids) > 0 || recipientsConfiguredEmpty(d.GetRawConfig()) {
+		payload.RecipientIDs = &ids
+	}
+	return payload
+}
+
+// additiveRecipientIDs keeps the recipients attached to the check outside of
+// Terraform, only detaching the managed ones removed from the configuration.
+func additiveRecipientIDs(current, previous, configured []string) []string {
+	removed := map[string]bool{}
+	for _, id := range previous {
+		removed[id] = true
+	}
+	for _, id := range configured {
+		delete(removed, id)
+	}
+
+	ids := []string{}
+	for _, id := range current {
+		if !removed[id] {
+			ids = append(ids, id)
+		}
+	}
+	return mergeIDs(ids, configured)
+}
+
+// recipientsConfiguredEmpty tells whether recipients is explicitly set to an
+// empty set in the configuration, which can't be told apart from an unset
+// attribute otherwise as it is computed.
+func recipientsConfiguredEmpty(config cty.Value) bool {
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+
+	recipients := config.GetAttr("recipients")
+	return recipients.IsKnown() && !recipients.IsNull() && recipients.LengthInt() == 0
+}
+
+// checkRecipientsCustomizeDiff resolves recipient_selectors and makes sure
+// every recipient referenced by the check exists before applying.
+func checkRecipientsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// An empty set doesn't produce any diff on its own for a computed attribute
+	if d.Get("recipients_mode").(string) == "authoritative" && recipientsConfiguredEmpty(d.GetRawConfig()) && d.Get("recipients").(*schema.Set).Len() > 0 {
+		if err := d.SetNew("recipients", []string{}); err != nil {
+			return err
+		}
+	}
+
+	if !d.NewValueKnown("recipient_selectors") {
+		return d.SetNewComputed("selected_recipients")
+	}
+
+	// Selectors are evaluated on every plan, as recipients can start or stop
+	// matching them outside of Terraform
+	selectors := expandRecipientSelectors(d.Get("recipient_selectors").([]interface{}))
+	if len(selectors) == 0 && !d.HasChanges("recipients", "recipient_selectors") {
+		return nil
+	}
+
+	var ids []string
+	if d.NewValueKnown("recipients") {
+		for _, id := range d.Get("recipients").(*schema.Set).List() {
+			ids = append(ids, id.(string))
+		}
+	}
+
+	if len(selectors) == 0 && len(ids) == 0 {
+		if d.Get("selected_recipients").(*schema.Set).Len() > 0 {
+			return d.SetNew("selected_recipients", []string{})
+		}
+		return nil
+	}
+
+	recipients, err := listRecipients(ctx, meta.(*providerMeta).client)
+	if err != nil {
+		return fmt.Errorf("reading recipients from the API: %w", err)
+	}
+
+	selected, err := resolveRecipientSelectors(recipients, selectors)
+	errs := []error{err}
+
+	if unknown := unknownRecipientIDs(recipients, ids); len(unknown) > 0 {
+		errs = append(errs, fmt.Errorf("recipients: no recipient found with ID %s", strings.Join(unknown, ", ")))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	current := listToStringSlice(d.Get("selected_recipients").(*schema.Set).List())
+	sort.Strings(current)
+	if slices.Equal(current, selected) {
+		return nil
+	}
+
+	return d.SetNew("selected_recipients", selected)
+}
+
+// validateCheckURL warns when credentials are embedded in the URL, as they
+// end up in plans and logs where basic_auth would keep them sensitive.
+// Non-routable targets are reported by checkCustomizeDiff, depending on the
+// provider configuration.
+func validateCheckURL(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if u, err := url.Parse(v.(string)); err == nil && u.User != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "Credentials in check URL",
+			Detail:        "The URL contains user information, which is stored and displayed in clear text. Use the basic_auth block instead.",
+			AttributePath: path,
+		})
+	}
+
+	return diags
+}
+
+// checkURLWithBasicAuth embeds the credentials in the URL, which is how the
+// API expects basic authentication to be configured.
+func checkURLWithBasicAuth(rawURL, username, password string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+
+	u.User = url.UserPassword(username, password)
+	return u.String()
+}
+
+// stripCheckURLCredentials removes the user information from the URL and
+// returns it separately.
+func stripCheckURLCredentials(rawURL string) (string, string, string) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User == nil {
+		return rawURL, "", ""
+	}
+
+	username := u.User.Username()
+	password, _ := u.User.Password()
+	u.User = nil
 
-	_, _, err := client.Check.Update(d.Id(), constructCheckPayload(d))
+	return u.String(), username, password
+}
+
+func checkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	check, err := updateCheckResource(ctx, d, meta)
 	if err != nil {
-		return fmt.Errorf("updating check with the API: %w", err)
+		return diag.FromErr(err)
+	}
+
+	// The result of the check is the same as before unless it's checked
+	// differently
+	if d.HasChanges(checkResultAttributes...) {
+		if err := waitForCheck(ctx, d, meta, check.LastCheckAt); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	return checkRead(d, meta)
+	return checkRead(ctx, d, meta)
+}
+
+// checkResultAttributes are the attributes whose change leads to a new result
+// of the check, waited for by wait_for_status.
+var checkResultAttributes = []string{
+	"url", "type", "period", "apdex_t", "enabled", "string_match", "disabled_locations",
+	"custom_headers", "http_verb", "http_body", "basic_auth",
 }
 
-func checkDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*updown.Client)
-	checkDeleted, _, err := client.Check.Remove(d.Id())
+// updateCheckResource sends the configuration to the API, the lock being
+// released before waiting for the check.
+func updateCheckResource(ctx context.Context, d *schema.ResourceData, meta interface{}) (updown.Check, error) {
+	client := meta.(*providerMeta).client
 
+	payload := constructCheckPayload(d)
+	if err := addSelectedRecipients(ctx, client, d, &payload); err != nil {
+		return updown.Check{}, err
+	}
+
+	// updown_check_recipient resources update the same recipients list
+	resourceLocks.Lock(checkLockKey(d.Id()))
+	defer resourceLocks.Unlock(checkLockKey(d.Id()))
+
+	// The recipients attached elsewhere are kept in additive mode
+	var current []string
+	if d.Get("recipients_mode").(string) == "additive" {
+		check, err := getCheck(ctx, client, d.Id())
+		if err != nil {
+			return updown.Check{}, fmt.Errorf("reading check from the API: %w", err)
+		}
+		current = check.RecipientIDs
+	}
+
+	check, err := updateCheck(ctx, client, d.Id(), withRecipients(d, payload, current))
 	if err != nil {
-		return fmt.Errorf("removing check from the API: %w", err)
+		return updown.Check{}, fmt.Errorf("updating check with the API: %w", err)
 	}
 
-	if !checkDeleted {
-		return fmt.Errorf("check couldn't be deleted")
+	// The empty mute_until is omitted from the payload, so the check would
+	// stay muted
+	if checkUnmuted(d) {
+		check, err = updateCheck(ctx, client, d.Id(), checkMutePayload{})
+		if err != nil {
+			return updown.Check{}, fmt.Errorf("unmuting check with the API: %w", err)
+		}
 	}
 
-	return nil
+	return check, nil
 }
 
-func checkExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	err := checkRead(d, meta)
-	return err == nil, err
+func checkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	checkDeleted, err := removeCheck(ctx, client, d.Id())
+
+	if err != nil {
+		return diag.Errorf("removing check from the API: %s", err)
+	}
+
+	if !checkDeleted {
+		return diag.Errorf("check couldn't be deleted")
+	}
+
+	return nil
 }
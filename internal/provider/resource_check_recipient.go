@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func checkRecipientResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "`updown_check_recipient` attaches a recipient to a check without managing the check itself. " +
+			"The `updown_check` resource should leave `recipients` unset or use `recipients_mode = \"additive\"` so that both don't fight over the recipients.",
+
+		CreateContext: checkRecipientCreate,
+		ReadContext:   checkRecipientRead,
+		DeleteContext: checkRecipientDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: checkRecipientImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"check": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Token of the check.",
+			},
+			"recipient": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the recipient to notify for the check.",
+			},
+		},
+	}
+}
+
+func checkRecipientID(token, recipientID string) string {
+	return token + "/" + recipientID
+}
+
+func parseCheckRecipientID(id string) (string, string, error) {
+	token, recipientID, found := strings.Cut(id, "/")
+	if !found || token == "" || recipientID == "" {
+		return "", "", fmt.Errorf("unexpected ID %q, expected <check_token>/<recipient_id>", id)
+	}
+	return token, recipientID, nil
+}
+
+func checkRecipientCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	token := d.Get("check").(string)
+	recipientID := d.Get("recipient").(string)
+
+	resourceLocks.Lock(checkLockKey(token))
+	defer resourceLocks.Unlock(checkLockKey(token))
+
+	check, err := getCheck(ctx, client, token)
+	if err != nil {
+		return diag.Errorf("reading check from the API: %s", err)
+	}
+
+	_, err = updateCheck(ctx, client, token, checkRecipientsPayload{
+		RecipientIDs: mergeIDs(check.RecipientIDs, []string{recipientID}),
+	})
+	if err != nil {
+		return diag.Errorf("attaching recipient to the check with the API: %s", err)
+	}
+
+	d.SetId(checkRecipientID(token, recipientID))
+
+	return checkRecipientRead(ctx, d, meta)
+}
+
+func checkRecipientRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	token, recipientID, err := parseCheckRecipientID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	check, err := getCheck(ctx, client, token)
+	if isNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("reading check from the API: %s", err)
+	}
+
+	attached := false
+	for _, id := range check.RecipientIDs {
+		if id == recipientID {
+			attached = true
+		}
+	}
+
+	// Detached outside of Terraform
+	if !attached {
+		d.SetId("")
+		return nil
+	}
+
+	for k, v := range map[string]interface{}{
+		"check":     token,
+		"recipient": recipientID,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func checkRecipientDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	token, recipientID, err := parseCheckRecipientID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resourceLocks.Lock(checkLockKey(token))
+	defer resourceLocks.Unlock(checkLockKey(token))
+
+	check, err := getCheck(ctx, client, token)
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("reading check from the API: %s", err)
+	}
+
+	ids := []string{}
+	for _, id := range check.RecipientIDs {
+		if id != recipientID {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) == len(check.RecipientIDs) {
+		return nil
+	}
+
+	_, err = updateCheck(ctx, client, token, checkRecipientsPayload{RecipientIDs: ids})
+	if err != nil {
+		return diag.Errorf("detaching recipient from the check with the API: %s", err)
+	}
+
+	return nil
+}
+
+func checkRecipientImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseCheckRecipientID(d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
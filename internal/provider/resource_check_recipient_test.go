@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccUpdownCheckRecipient_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	resourceName := "updown_check_recipient.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckUpdownCheckRecipientDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUpdownCheckRecipientConfig_basic(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUpdownCheckRecipientExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "check", "updown_check.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "recipient", "updown_recipient.test", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseCheckRecipientID(t *testing.T) {
+	token, recipientID, err := parseCheckRecipientID(checkRecipientID("ab12", "email:123456789"))
+	if err != nil || token != "ab12" || recipientID != "email:123456789" {
+		t.Errorf("unexpected result: %q, %q, %v", token, recipientID, err)
+	}
+
+	for _, id := range []string{"ab12", "/email:1", "ab12/", ""} {
+		if _, _, err := parseCheckRecipientID(id); err == nil {
+			t.Errorf("expected an error for %q", id)
+		}
+	}
+}
+
+func testAccCheckUpdownCheckRecipientDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "updown_check_recipient" {
+			continue
+		}
+	}
+	return nil
+}
+
+func testAccCheckUpdownCheckRecipientExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Check Recipient ID is set")
+		}
+
+		return nil
+	}
+}
+
+func testAccUpdownCheckRecipientConfig_basic(rName string) string {
+	return fmt.Sprintf(`
+resource "updown_check" "test" {
+  url             = "https://example.com"
+  alias           = %[1]q
+  recipients_mode = "additive"
+}
+
+resource "updown_recipient" "test" {
+  type  = "webhook"
+  value = "https://example.com/%[1]s"
+}
+
+resource "updown_check_recipient" "test" {
+  check     = updown_check.test.id
+  recipient = updown_recipient.test.id
+}
+`, rName)
+}
@@ -0,0 +1,496 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sergo-techhub/updown"
+)
+
+// maintenanceWindowResource mutes checks for a while, restoring their
+// previous mute_until afterwards. It's served by the framework as the SDK
+// has no private state to record the previous values in.
+type maintenanceWindowResource struct {
+	meta *providerMeta
+}
+
+var (
+	_ resource.ResourceWithConfigure      = &maintenanceWindowResource{}
+	_ resource.ResourceWithValidateConfig = &maintenanceWindowResource{}
+	_ resource.ResourceWithModifyPlan     = &maintenanceWindowResource{}
+)
+
+func newMaintenanceWindowResource() resource.Resource {
+	return &maintenanceWindowResource{}
+}
+
+type maintenanceWindowModel struct {
+	ID       types.String `tfsdk:"id"`
+	Checks   types.Set    `tfsdk:"checks"`
+	Start    types.String `tfsdk:"start"`
+	End      types.String `tfsdk:"end"`
+	Duration types.String `tfsdk:"duration"`
+	EndsAt   types.String `tfsdk:"ends_at"`
+	Pending  types.Bool   `tfsdk:"restore_pending"`
+}
+
+// maintenanceWindowMutesKey is the private state key holding the
+// maintenanceMute of every check by token.
+const maintenanceWindowMutesKey = "mutes"
+
+// maintenanceMute records the mute_until of a check before the window, and
+// the one the API returned once muted to tell whether it changed since.
+type maintenanceMute struct {
+	Previous string `json:"previous"`
+	Applied  string `json:"applied"`
+}
+
+func (r *maintenanceWindowResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_maintenance_window"
+}
+
+func (r *maintenanceWindowResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`updown_maintenance_window` mutes checks during a maintenance, restoring their previous `mute_until` on destroy or on the first apply once the window ended. " +
+			"The `updown_check` resources of the checks setting `mute_until` or `mute_for` should ignore changes to them so that both don't fight over it.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Identifier of the maintenance window.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"checks": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Tokens of the checks to mute.",
+			},
+			"start": schema.StringAttribute{
+				Optional:    true,
+				Description: "RFC 3339 start time of the window. The checks are muted when applying, which must happen once it started. Defaults to the time of apply.",
+			},
+			"end": schema.StringAttribute{
+				Optional:    true,
+				Description: "RFC 3339 end time of the window. Conflicts with `duration`.",
+			},
+			"duration": schema.StringAttribute{
+				Optional:    true,
+				Description: "Duration of the window (e.g. 45m or 2h) from `start`. Conflicts with `end`.",
+			},
+			"ends_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "RFC 3339 time the checks are muted until.",
+			},
+			"restore_pending": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the window ended with checks left to restore, which the next apply does.",
+			},
+		},
+	}
+}
+
+func (r *maintenanceWindowResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Not configured yet when validating
+	if req.ProviderData == nil {
+		return
+	}
+
+	meta, ok := req.ProviderData.(*providerMeta)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *providerMeta, got %T. This is always a bug in the provider.", req.ProviderData))
+		return
+	}
+	r.meta = meta
+}
+
+func (r *maintenanceWindowResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config maintenanceWindowModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values are validated when applying
+	if config.End.IsUnknown() || config.Duration.IsUnknown() || config.Start.IsUnknown() {
+		return
+	}
+
+	if config.End.IsNull() == config.Duration.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("end"), "Invalid maintenance window", "Exactly one of end or duration must be set.")
+		return
+	}
+
+	if _, err := maintenanceWindowEnd(config, time.Now()); err != nil {
+		resp.Diagnostics.AddError("Invalid maintenance window", err.Error())
+	}
+}
+
+// ModifyPlan keeps the end of the window when only the checks change, so that
+// a window with a duration isn't extended, and resolves it when it doesn't
+// depend on the time of apply.
+func (r *maintenanceWindowResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan maintenanceWindowModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Restores the checks of a window which ended
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("restore_pending"), false)...)
+
+	if !req.State.Raw.IsNull() {
+		var state maintenanceWindowModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if plan.Start.Equal(state.Start) && plan.End.Equal(state.End) && plan.Duration.Equal(state.Duration) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ends_at"), state.EndsAt)...)
+			return
+		}
+	}
+
+	if plan.End.IsUnknown() || plan.Start.IsUnknown() || plan.Duration.IsUnknown() || (plan.Start.IsNull() && plan.End.IsNull()) {
+		return
+	}
+
+	if end, err := maintenanceWindowEnd(plan, time.Now()); err == nil {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ends_at"), end.Format(time.RFC3339))...)
+	}
+}
+
+// maintenanceWindowEnd returns the end of the window, now being its start
+// when it isn't set.
+func maintenanceWindowEnd(m maintenanceWindowModel, now time.Time) (time.Time, error) {
+	start := now
+	if !m.Start.IsNull() {
+		var err error
+		if start, err = time.Parse(time.RFC3339, m.Start.ValueString()); err != nil {
+			return time.Time{}, fmt.Errorf("start: invalid RFC 3339 time %q", m.Start.ValueString())
+		}
+	}
+
+	var end time.Time
+	if !m.End.IsNull() {
+		var err error
+		if end, err = time.Parse(time.RFC3339, m.End.ValueString()); err != nil {
+			return time.Time{}, fmt.Errorf("end: invalid RFC 3339 time %q", m.End.ValueString())
+		}
+	} else {
+		duration, err := parseMuteDuration(m.Duration.ValueString())
+		if err != nil {
+			return time.Time{}, fmt.Errorf("duration: %w", err)
+		}
+		end = start.Add(duration)
+	}
+
+	if !end.After(start) {
+		return time.Time{}, fmt.Errorf("end: %s isn't after the start of the window", end.Format(time.RFC3339))
+	}
+	return end.UTC(), nil
+}
+
+func (r *maintenanceWindowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan maintenanceWindowModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	now := time.Now()
+	end, err := maintenanceWindowEnd(plan, now)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid maintenance window", err.Error())
+		return
+	}
+
+	// The API can't mute checks later on
+	if start, err := time.Parse(time.RFC3339, plan.Start.ValueString()); err == nil && start.After(now) {
+		resp.Diagnostics.AddAttributeError(path.Root("start"), "Maintenance window not started",
+			fmt.Sprintf("The window starts at %s, apply it once it started as checks can only be muted from now on.", start.Format(time.RFC3339)))
+		return
+	}
+	if !end.After(now) {
+		resp.Diagnostics.AddAttributeError(path.Root("end"), "Maintenance window already ended", fmt.Sprintf("The window ended at %s.", end.Format(time.RFC3339)))
+		return
+	}
+
+	var tokens []string
+	resp.Diagnostics.Append(plan.Checks.ElementsAs(ctx, &tokens, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		resp.Diagnostics.AddError("Generating maintenance window ID", err.Error())
+		return
+	}
+	plan.ID = types.StringValue(hex.EncodeToString(id))
+	plan.EndsAt = types.StringValue(end.Format(time.RFC3339))
+	plan.Pending = types.BoolValue(false)
+
+	mutes := map[string]maintenanceMute{}
+	err = muteChecks(ctx, r.meta.client, tokens, end, mutes)
+
+	// Saved even on error, so that the checks muted so far are restored
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setMaintenanceMutes(ctx, resp.Private, mutes)...)
+	if err != nil {
+		resp.Diagnostics.AddError("Muting checks with the API", err.Error())
+	}
+}
+
+func (r *maintenanceWindowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state maintenanceWindowModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mutes, diags := getMaintenanceMutes(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The end of the window shows up as a change, the checks being restored
+	// by the next apply rather than while refreshing
+	state.Pending = types.BoolValue(maintenanceWindowRestorePending(state, mutes, time.Now()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// maintenanceWindowRestorePending tells whether the window ended with checks
+// left to restore.
+func maintenanceWindowRestorePending(m maintenanceWindowModel, mutes map[string]maintenanceMute, now time.Time) bool {
+	end, err := time.Parse(time.RFC3339, m.EndsAt.ValueString())
+	return len(mutes) > 0 && err == nil && !now.Before(end)
+}
+
+func (r *maintenanceWindowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state maintenanceWindowModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mutes, diags := getMaintenanceMutes(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+
+	var tokens []string
+	resp.Diagnostics.Append(plan.Checks.ElementsAs(ctx, &tokens, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	now := time.Now()
+	end, err := maintenanceWindowEnd(plan, now)
+	if !plan.EndsAt.IsUnknown() {
+		end, err = time.Parse(time.RFC3339, plan.EndsAt.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid maintenance window", err.Error())
+		return
+	}
+	plan.EndsAt = types.StringValue(end.UTC().Format(time.RFC3339))
+	expired := !end.After(now)
+
+	// The checks removed from the window are restored, as well as every
+	// check once it ended
+	removed := map[string]maintenanceMute{}
+	for token, mute := range mutes {
+		if expired || !slices.Contains(tokens, token) {
+			removed[token] = mute
+			delete(mutes, token)
+		}
+	}
+	err = restoreChecks(ctx, r.meta.client, removed, expired, now)
+	for token, mute := range removed {
+		mutes[token] = mute // Kept when they couldn't be restored
+	}
+
+	// The other ones are muted until the end of the window
+	if err == nil && !expired {
+		toMute := tokens
+		if plan.EndsAt.Equal(state.EndsAt) {
+			toMute = nil
+			for _, token := range tokens {
+				if _, ok := mutes[token]; !ok {
+					toMute = append(toMute, token)
+				}
+			}
+		}
+		err = muteChecks(ctx, r.meta.client, toMute, end, mutes)
+	}
+	plan.Pending = types.BoolValue(maintenanceWindowRestorePending(plan, mutes, now))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(setMaintenanceMutes(ctx, resp.Private, mutes)...)
+	if err != nil {
+		resp.Diagnostics.AddError("Updating the mute of checks with the API", err.Error())
+	}
+}
+
+func (r *maintenanceWindowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state maintenanceWindowModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	mutes, diags := getMaintenanceMutes(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	end, err := time.Parse(time.RFC3339, state.EndsAt.ValueString())
+	expired := err == nil && !time.Now().Before(end)
+
+	if err := restoreChecks(ctx, r.meta.client, mutes, expired, time.Now()); err != nil {
+		resp.Diagnostics.AddError("Restoring checks at the end of the maintenance window", err.Error())
+	}
+}
+
+// muteChecks mutes the checks until end, recording their previous mute_until
+// unless they're already part of mutes.
+func muteChecks(ctx context.Context, client *updown.Client, tokens []string, end time.Time, mutes map[string]maintenanceMute) error {
+	sort.Strings(tokens)
+	for _, token := range tokens {
+		if err := muteCheck(ctx, client, token, end, mutes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func muteCheck(ctx context.Context, client *updown.Client, token string, end time.Time, mutes map[string]maintenanceMute) error {
+	// updown_check resources update the same checks
+	resourceLocks.Lock(checkLockKey(token))
+	defer resourceLocks.Unlock(checkLockKey(token))
+
+	mute, ok := mutes[token]
+	if !ok {
+		check, err := getCheck(ctx, client, token)
+		if err != nil {
+			return fmt.Errorf("reading check %s: %w", token, err)
+		}
+		mute.Previous = check.MuteUntil
+	}
+
+	check, err := updateCheck(ctx, client, token, checkMutePayload{MuteUntil: end.Format(time.RFC3339)})
+	if err != nil {
+		return fmt.Errorf("muting check %s: %w", token, err)
+	}
+	mute.Applied = check.MuteUntil
+	mutes[token] = mute
+
+	return nil
+}
+
+// restoreChecks restores the previous mute_until of the checks, removing
+// them from mutes. The checks deleted meanwhile are ignored.
+func restoreChecks(ctx context.Context, client *updown.Client, mutes map[string]maintenanceMute, expired bool, now time.Time) error {
+	tokens := make([]string, 0, len(mutes))
+	for token := range mutes {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+
+	for _, token := range tokens {
+		if err := restoreCheck(ctx, client, token, mutes[token], expired, now); err != nil {
+			return err
+		}
+		delete(mutes, token)
+	}
+	return nil
+}
+
+func restoreCheck(ctx context.Context, client *updown.Client, token string, mute maintenanceMute, expired bool, now time.Time) error {
+	resourceLocks.Lock(checkLockKey(token))
+	defer resourceLocks.Unlock(checkLockKey(token))
+
+	check, err := getCheck(ctx, client, token)
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading check %s: %w", token, err)
+	}
+
+	muteUntil, ok := restoredMuteUntil(mute, check.MuteUntil, expired, now)
+	if !ok {
+		return nil
+	}
+
+	if _, err := updateCheck(ctx, client, token, checkMutePayload{MuteUntil: muteUntil}); err != nil {
+		return fmt.Errorf("restoring mute of check %s: %w", token, err)
+	}
+	return nil
+}
+
+// restoredMuteUntil returns the mute_until to restore on a check whose
+// current value is current, and false when there's nothing to restore. A mute
+// changed since the window was applied is left alone, unless it's the mute of
+// the window which the API cleared once expired.
+func restoredMuteUntil(mute maintenanceMute, current string, expired bool, now time.Time) (string, bool) {
+	if current != mute.Applied && (!expired || current != "") {
+		return "", false
+	}
+
+	// A previous mute which ended during the window isn't restored
+	previous := mute.Previous
+	if t, err := time.Parse(time.RFC3339, previous); err == nil && !t.After(now) {
+		previous = ""
+	}
+
+	return previous, previous != current
+}
+
+// privateState is implemented by the private state of requests and
+// responses, whose type is internal to the framework.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+func getMaintenanceMutes(ctx context.Context, private privateState) (map[string]maintenanceMute, diag.Diagnostics) {
+	mutes := map[string]maintenanceMute{}
+
+	b, diags := private.GetKey(ctx, maintenanceWindowMutesKey)
+	if diags.HasError() || len(b) == 0 {
+		return mutes, diags
+	}
+
+	if err := json.Unmarshal(b, &mutes); err != nil {
+		diags.AddError("Reading the previous mutes of the checks", err.Error())
+	}
+	return mutes, diags
+}
+
+func setMaintenanceMutes(ctx context.Context, private privateState, mutes map[string]maintenanceMute) diag.Diagnostics {
+	b, err := json.Marshal(mutes)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Recording the previous mutes of the checks", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, maintenanceWindowMutesKey, b)
+}
@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/sergo-techhub/updown"
+)
+
+// newMuteTestClient serves the checks of the map, PUT requests updating their
+// mute_until.
+func newMuteTestClient(t *testing.T, muteUntil map[string]string) *updown.Client {
+	var mu sync.Mutex
+	return newHandlerTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		token := strings.TrimPrefix(r.URL.Path, "/api/checks/")
+		if _, ok := muteUntil[token]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Method == http.MethodPut {
+			var payload checkMutePayload
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			muteUntil[token] = payload.MuteUntil
+		}
+		_ = json.NewEncoder(w).Encode(updown.Check{Token: token, MuteUntil: muteUntil[token]})
+	})
+}
+
+func TestMaintenanceWindowEnd(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	for name, tc := range map[string]struct {
+		model    maintenanceWindowModel
+		expected string
+	}{
+		"duration": {
+			model:    maintenanceWindowModel{Start: types.StringNull(), End: types.StringNull(), Duration: types.StringValue("45m")},
+			expected: "2024-05-01T10:45:00Z",
+		},
+		"start and duration": {
+			model:    maintenanceWindowModel{Start: types.StringValue("2024-05-01T14:00:00+02:00"), End: types.StringNull(), Duration: types.StringValue("2h")},
+			expected: "2024-05-01T14:00:00Z",
+		},
+		"end": {
+			model:    maintenanceWindowModel{Start: types.StringNull(), End: types.StringValue("2024-05-01T12:30:00Z"), Duration: types.StringNull()},
+			expected: "2024-05-01T12:30:00Z",
+		},
+		"end before start": {
+			model: maintenanceWindowModel{Start: types.StringValue("2024-05-01T12:00:00Z"), End: types.StringValue("2024-05-01T11:00:00Z"), Duration: types.StringNull()},
+		},
+		"invalid end": {
+			model: maintenanceWindowModel{Start: types.StringNull(), End: types.StringValue("tomorrow"), Duration: types.StringNull()},
+		},
+		"invalid duration": {
+			model: maintenanceWindowModel{Start: types.StringNull(), End: types.StringNull(), Duration: types.StringValue("soon")},
+		},
+	} {
+		end, err := maintenanceWindowEnd(tc.model, now)
+		if tc.expected == "" {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %s", name, end)
+			}
+		} else if err != nil || end.Format(time.RFC3339) != tc.expected {
+			t.Errorf("%s: got %s (%v), expected %s", name, end, err, tc.expected)
+		}
+	}
+}
+
+func TestRestoredMuteUntil(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	applied := "2024-05-01T13:00:00Z"
+
+	for name, tc := range map[string]struct {
+		previous, current string
+		expired           bool
+		expected          string
+		restore           bool
+	}{
+		"not muted before":     {"", applied, false, "", true},
+		"muted forever before": {"forever", applied, false, "forever", true},
+		"muted later before":   {"2024-05-02T00:00:00Z", applied, false, "2024-05-02T00:00:00Z", true},
+		"mute ended meanwhile": {"2024-05-01T11:00:00Z", applied, false, "", true},
+		"changed since":        {"forever", "recovery", false, "", false},
+		"unmuted since":        {"forever", "", false, "", false},
+		"cleared once expired": {"forever", "", true, "forever", true},
+		"nothing to restore":   {"", "", true, "", false},
+	} {
+		muteUntil, restore := restoredMuteUntil(maintenanceMute{Previous: tc.previous, Applied: applied}, tc.current, tc.expired, now)
+		if muteUntil != tc.expected || restore != tc.restore {
+			t.Errorf("%s: got %q, %t, expected %q, %t", name, muteUntil, restore, tc.expected, tc.restore)
+		}
+	}
+}
+
+func TestMaintenanceWindowRestorePending(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mutes := map[string]maintenanceMute{"abcd": {Applied: "2024-05-01T12:00:00Z"}}
+
+	for name, tc := range map[string]struct {
+		endsAt   string
+		mutes    map[string]maintenanceMute
+		expected bool
+	}{
+		"ongoing":          {"2024-05-01T13:00:00Z", mutes, false},
+		"ended":            {"2024-05-01T12:00:00Z", mutes, true},
+		"already restored": {"2024-05-01T11:00:00Z", map[string]maintenanceMute{}, false},
+		"unknown end":      {"", mutes, false},
+	} {
+		m := maintenanceWindowModel{EndsAt: types.StringValue(tc.endsAt)}
+		if pending := maintenanceWindowRestorePending(m, tc.mutes, now); pending != tc.expected {
+			t.Errorf("%s: got %t, expected %t", name, pending, tc.expected)
+		}
+	}
+}
+
+func TestMuteAndRestoreChecks(t *testing.T) {
+	muteUntil := map[string]string{"abcd": "", "efgh": "forever", "ijkl": "recovery"}
+	client := newMuteTestClient(t, muteUntil)
+	end := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	mutes := map[string]maintenanceMute{}
+	if err := muteChecks(context.Background(), client, []string{"abcd", "efgh", "ijkl"}, end, mutes); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for token, value := range muteUntil {
+		if value != end.Format(time.RFC3339) {
+			t.Errorf("%s: got mute_until %q, expected %s", token, value, end.Format(time.RFC3339))
+		}
+	}
+
+	// Extending the window keeps the previous values
+	if err := muteChecks(context.Background(), client, []string{"abcd"}, end.Add(time.Hour), mutes); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Changed outside of the window
+	muteUntil["ijkl"] = "forever"
+
+	if err := restoreChecks(context.Background(), client, mutes, false, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(mutes) != 0 {
+		t.Errorf("expected every check to be restored, got %v", mutes)
+	}
+	for token, expected := range map[string]string{"abcd": "", "efgh": "forever", "ijkl": "forever"} {
+		if muteUntil[token] != expected {
+			t.Errorf("%s: got mute_until %q, expected %q", token, muteUntil[token], expected)
+		}
+	}
+
+	// Checks deleted meanwhile are ignored
+	if err := restoreChecks(context.Background(), client, map[string]maintenanceMute{"zzzz": {}}, true, time.Now()); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
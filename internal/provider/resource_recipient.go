@@ -1,44 +1,101 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/sergo-techhub/updown"
 )
 
+// managedRecipientTypes are the types of recipients which can be set up
+// through the API, the other ones require the web UI.
+var managedRecipientTypes = []string{
+	"email",
+	"sms",
+	"webhook",
+	"slack_compatible",
+}
+
 func recipientResource() *schema.Resource {
 	return &schema.Resource{
 		Description: "`updown_recipient` defines a recipient",
 
-		Create: recipientCreate,
-		Read:   recipientRead,
-		Delete: recipientDelete,
-		Exists: recipientExists,
+		CreateContext: withTimeout(schema.TimeoutCreate, "recipient", recipientCreate),
+		ReadContext:   withTimeout(schema.TimeoutRead, "recipient", recipientRead),
+		UpdateContext: withTimeout(schema.TimeoutUpdate, "recipient", recipientUpdate),
+		DeleteContext: withTimeout(schema.TimeoutDelete, "recipient", recipientDelete),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: recipientImport,
 		},
 
+		Identity: idIdentity("id", "ID of the recipient."),
+
+		CustomizeDiff: recipientCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Type of recipient ('email', 'sms', 'webhook' or 'slack_compatible' only). The other integrations (slack, telegram, zapier, statuspage, etc.) require the web UI to setup.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(managedRecipientTypes, false),
+			},
+			"value": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "Type of recipient ('email', 'sms', 'webhook' or 'slack_compatible' only). The other integrations (slack, telegram, zapier, statuspage, etc.) require the web UI to setup.",
+				Description: "The recipient value (email address, phone number in the E.164 format or https URL). Differences in case and formatting with the value stored by the API are ignored.",
 				ForceNew:    true,
+				DiffSuppressFunc: func(_, old, new string, d *schema.ResourceData) bool {
+					recipientType := d.Get("type").(string)
+					return normalizeRecipientValue(recipientType, old) == normalizeRecipientValue(recipientType, new)
+				},
 			},
-			"value": {
+			"name": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The recipient value (email address, phone number or URL)",
+				Optional:    true,
+				Computed:    true,
+				Description: "Display name of the recipient, defaults to the value. The API can't rename a recipient, so changing it recreates the recipient and detaches it from its checks.",
 				ForceNew:    true,
 			},
+			"adopt_existing": adoptExistingSchema("type and value"),
+			"selected": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Adds the recipient to every existing check when it is created. This is a one-shot action: changing it afterwards has no effect and doesn't recreate the recipient.",
+			},
 		},
 	}
 }
 
-func constructRecipientPayload(d *schema.ResourceData) updown.RecipientItem {
-	payload := updown.RecipientItem{}
+// recipientCustomizeDiff validates the value against the type of the
+// recipient, which can't be done by a ValidateFunc.
+func recipientCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("value") {
+		return nil
+	}
+
+	if err := validateRecipientValue(d.Get("type").(string), d.Get("value").(string)); err != nil {
+		return fmt.Errorf("value: %w", err)
+	}
+
+	return nil
+}
+
+func constructRecipientPayload(d *schema.ResourceData) recipientPayload {
+	payload := recipientPayload{}
 	if v, ok := d.GetOk("type"); ok {
 		payload.Type = updown.RecipientType(v.(string))
 	}
@@ -47,62 +104,114 @@ func constructRecipientPayload(d *schema.ResourceData) updown.RecipientItem {
 		payload.Value = v.(string)
 	}
 
+	if v, ok := d.GetOk("name"); ok {
+		payload.Name = v.(string)
+	}
+
+	if v, ok := d.GetOk("selected"); ok {
+		payload.Selected = v.(bool)
+	}
+
 	return payload
 }
 
-func recipientCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*updown.Client)
+func recipientCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
 
-	recipient, _, err := client.Recipient.Add(constructRecipientPayload(d))
+	// Recipients can't be updated, the existing one is kept as is
+	if shouldAdoptExisting(d, meta) {
+		recipients, err := listRecipients(ctx, client)
+		if err != nil {
+			return diag.Errorf("reading recipients from the API: %s", err)
+		}
+
+		recipient, found, err := findAdoptableRecipient(recipients, d.Get("type").(string), d.Get("value").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if found {
+			d.SetId(recipient.ID)
+			if diags := recipientRead(ctx, d, meta); diags.HasError() {
+				return diags
+			}
+			return adoptedWarning("recipient", recipient.ID, recipient.Name)
+		}
+	}
+
+	recipient, err := addRecipient(ctx, client, constructRecipientPayload(d))
 	if err != nil {
-		return fmt.Errorf("creating Recipient with the API")
+		return diag.Errorf("creating recipient with the API: %s", err)
 	}
 
 	d.SetId(recipient.ID)
 
-	return recipientRead(d, meta)
+	return recipientRead(ctx, d, meta)
 }
 
-func recipientRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*updown.Client)
-	recipients, _, err := client.Recipient.List()
+func recipientRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	recipients, err := listRecipients(ctx, client)
 
 	if err != nil {
-		return fmt.Errorf("reading recipients from the API")
+		return diag.Errorf("reading recipients from the API: %s", err)
 	}
 
 	for _, r := range recipients {
 		if d.Id() == r.ID {
-			for k, v := range map[string]interface{}{
-				"type":  string(r.Type),
-				"value": r.Name,
-			} {
-				if err := d.Set(k, v); err != nil {
-					return err
-				}
-			}
+			return diag.FromErr(setRecipientData(d, r))
 		}
 	}
 
+	// Deleted outside of Terraform, it's planned to be created again
+	d.SetId("")
 	return nil
 }
 
-func recipientDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*updown.Client)
-	RecipientDeleted, _, err := client.Recipient.Remove(d.Id())
+// setRecipientData stores the recipient returned by the API in the resource
+// data.
+func setRecipientData(d *schema.ResourceData, r updown.Recipient) error {
+	// The API only returns the value as the name of the recipient unless it
+	// has a custom one, it is kept from the state then
+	value := r.Value
+	if value == "" {
+		value = d.Get("value").(string)
+	}
+	if value == "" {
+		value = r.Name // Not set on import
+	}
+
+	for k, v := range map[string]interface{}{
+		"type":     string(r.Type),
+		"value":    value,
+		"name":     r.Name,
+		"selected": d.Get("selected").(bool),
+	} {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+
+	return setIDIdentity(d, "id")
+}
+
+// recipientUpdate only stores the arguments which don't need a call to the API,
+// as the recipients can't be updated in place.
+func recipientUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return recipientRead(ctx, d, meta)
+}
+
+func recipientDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	RecipientDeleted, err := removeRecipient(ctx, client, d.Id())
 
 	if err != nil {
-		return fmt.Errorf("removing Recipient from the API")
+		return diag.Errorf("removing recipient from the API: %s", err)
 	}
 
 	if !RecipientDeleted {
-		return fmt.Errorf("recipient couldn't be deleted")
+		return diag.Errorf("recipient couldn't be deleted")
 	}
 
 	return nil
 }
-
-func recipientExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	err := recipientRead(d, meta)
-	return err == nil, err
-}
@@ -1,7 +1,9 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -69,6 +71,106 @@ func TestAccUpdownRecipient_slackCompatible(t *testing.T) {
 	})
 }
 
+func TestAccUpdownRecipient_nameAndSelected(t *testing.T) {
+	email := fmt.Sprintf("test-%s@example.com", acctest.RandString(10))
+	resourceName := "updown_recipient.test"
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckUpdownRecipientDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUpdownRecipientConfig_named(email, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUpdownRecipientExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "Terraform tests"),
+					resource.TestCheckResourceAttr(resourceName, "value", email),
+					resource.TestCheckResourceAttr(resourceName, "selected", "true"),
+					testAccCheckUpdownRecipientID(resourceName, &id),
+				),
+			},
+			// Unsetting selected doesn't recreate the recipient
+			{
+				Config: testAccUpdownRecipientConfig_named(email, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "selected", "false"),
+					testAccCheckUpdownRecipientSameID(resourceName, &id),
+				),
+			},
+		},
+	})
+}
+
+func TestRecipientResource_validation(t *testing.T) {
+	for name, tc := range map[string]struct {
+		config   map[string]interface{}
+		expected string
+	}{
+		"unsupported type": {
+			config:   map[string]interface{}{"type": "telegram", "value": "foo"},
+			expected: "expected type to be one of",
+		},
+		"invalid email": {
+			config:   map[string]interface{}{"type": "email", "value": "foo@"},
+			expected: "value: must be a valid email address",
+		},
+		"invalid phone number": {
+			config:   map[string]interface{}{"type": "sms", "value": "06 12 34 56 78"},
+			expected: "value: must be a phone number in the E.164 format",
+		},
+		"http webhook": {
+			config:   map[string]interface{}{"type": "webhook", "value": "http://example.com/hook"},
+			expected: "value: must be an absolute https URL",
+		},
+		"formatted phone number": {
+			config: map[string]interface{}{"type": "sms", "value": "+33 6 12 34 56 78"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(tc.config)
+
+			var err error
+			if diags := recipientResource().Validate(config); diags.HasError() {
+				err = fmt.Errorf("%s", diags[0].Summary)
+			} else {
+				_, err = recipientResource().Diff(context.Background(), nil, config, &providerMeta{})
+			}
+
+			switch {
+			case tc.expected == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case tc.expected != "" && (err == nil || !strings.Contains(err.Error(), tc.expected)):
+				t.Errorf("expected error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestRecipientResource_valueDiffSuppressed(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "sms:123",
+		Attributes: map[string]string{
+			"id":       "sms:123",
+			"type":     "sms",
+			"value":    "+33612345678",
+			"name":     "+33612345678",
+			"selected": "false",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"type": "sms", "value": "+33 6 12-34-56-78"})
+
+	diff, err := recipientResource().Diff(context.Background(), state, config, &providerMeta{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no diff, got %v", diff.Attributes)
+	}
+}
+
 func testAccCheckUpdownRecipientDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "updown_recipient" {
@@ -93,6 +195,32 @@ func testAccCheckUpdownRecipientExists(resourceName string) resource.TestCheckFu
 	}
 }
 
+func testAccCheckUpdownRecipientID(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func testAccCheckUpdownRecipientSameID(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		if rs.Primary.ID != *id {
+			return fmt.Errorf("Recipient was recreated: %s became %s", *id, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
 func testAccUpdownRecipientConfig_email(email string) string {
 	return fmt.Sprintf(`
 resource "updown_recipient" "test" {
@@ -119,3 +247,14 @@ resource "updown_recipient" "test" {
 }
 `
 }
+
+func testAccUpdownRecipientConfig_named(email string, selected bool) string {
+	return fmt.Sprintf(`
+resource "updown_recipient" "test" {
+  type     = "email"
+  value    = %[1]q
+  name     = "Terraform tests"
+  selected = %[2]t
+}
+`, email, selected)
+}
@@ -1,8 +1,17 @@
 package provider
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/sergo-techhub/updown"
@@ -12,24 +21,56 @@ func statusPageResource() *schema.Resource {
 	return &schema.Resource{
 		Description: "`updown_status_page` defines a status page",
 
-		Create: statusPageCreate,
-		Read:   statusPageRead,
-		Delete: statusPageDelete,
-		Update: statusPageUpdate,
-		Exists: statusPageExists,
+		CreateContext: withTimeout(schema.TimeoutCreate, "status page", statusPageCreate),
+		ReadContext:   withTimeout(schema.TimeoutRead, "status page", statusPageRead),
+		DeleteContext: withTimeout(schema.TimeoutDelete, "status page", statusPageDelete),
+		UpdateContext: withTimeout(schema.TimeoutUpdate, "status page", statusPageUpdate),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.All(
+			statusPageChecksCustomizeDiff,
+			statusPageAccessKeyCustomizeDiff,
+		),
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: statusPageImport,
 		},
 
+		Identity: idIdentity("token", "Token of the status page."),
+
 		Schema: map[string]*schema.Schema{
 			"checks": {
 				Type:        schema.TypeList,
-				Required:    true,
-				Description: "List of checks to show in the page (order is respected).",
+				Optional:    true,
+				Description: "List of checks to show in the page (order is respected in `authoritative` mode).",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ConflictsWith: []string{"check_aliases"},
+			},
+			"check_aliases": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Aliases of the checks to show in the page, as an alternative to `checks` (order is respected in `authoritative` mode).",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
+				ConflictsWith: []string{"checks"},
+			},
+			"checks_mode": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "authoritative",
+				Description: "How `checks` is reconciled: `authoritative` shows exactly the configured checks, `additive` only makes sure they are shown and leaves the ones added by `updown_status_page_check` resources or the web UI alone.",
+				ValidateFunc: validation.StringInSlice([]string{
+					"authoritative", "additive",
+				}, false),
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -51,11 +92,35 @@ func statusPageResource() *schema.Resource {
 				}, false),
 			},
 			"access_key": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Access key for protected pages. New protected pages require it unless `access_key_wo` or `access_key_rotation` is set, existing ones keep the key of the state.",
+				Sensitive:     true,
+				ConflictsWith: []string{"access_key_wo"},
+				ValidateFunc:  validation.StringIsNotEmpty,
+			},
+			"access_key_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				WriteOnly:     true,
+				Sensitive:     true,
+				Description:   "Write-only access key for protected pages, never stored in the state. Requires Terraform 1.11 or later.",
+				ConflictsWith: []string{"access_key"},
+				RequiredWith:  []string{"access_key_wo_version"},
+			},
+			"access_key_wo_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Version of `access_key_wo`, to be changed whenever the key has to be sent to the API again.",
+				RequiredWith: []string{"access_key_wo"},
+			},
+			"access_key_rotation": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Access key for protected pages (defaults to a random string if unset).",
-				Sensitive:   true,
+				Description: "Arbitrary value which generates a new random `access_key` for protected pages, and a new one whenever it changes. Only used when `access_key` isn't configured.",
 			},
+			"adopt_existing": adoptExistingSchema("name"),
 			"url": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -65,7 +130,7 @@ func statusPageResource() *schema.Resource {
 	}
 }
 
-func constructStatusPagePayload(d *schema.ResourceData) updown.StatusPageItem {
+func constructStatusPagePayload(d *schema.ResourceData) (updown.StatusPageItem, error) {
 	payload := updown.StatusPageItem{}
 
 	if v, ok := d.GetOk("checks"); ok {
@@ -93,36 +158,327 @@ func constructStatusPagePayload(d *schema.ResourceData) updown.StatusPageItem {
 		payload.AccessKey = v.(string)
 	}
 
-	return payload
+	if accessKeyWO, _ := d.GetRawConfigAt(cty.GetAttrPath("access_key_wo")); accessKeyWO.IsKnown() && !accessKeyWO.IsNull() {
+		// Only sent again when its version changes
+		if d.Id() == "" || d.HasChange("access_key_wo_version") {
+			payload.AccessKey = accessKeyWO.AsString()
+		}
+		return payload, nil
+	}
+
+	// A new key is generated for protected pages lacking one, or on rotation
+	accessKeyConfigured := !d.GetRawConfig().GetAttr("access_key").IsNull()
+	if !accessKeyConfigured && payload.Visibility == "protected" && (payload.AccessKey == "" || d.HasChange("access_key_rotation")) {
+		accessKey, err := generateAccessKey()
+		if err != nil {
+			return payload, err
+		}
+		payload.AccessKey = accessKey
+	}
+
+	return payload, nil
+}
+
+// generateAccessKey returns a random access key for protected pages.
+func generateAccessKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating access key: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// statusPageChecksCustomizeDiff reports duplicated checks, along with the ones
+// which don't exist, before the API silently drops them.
+func statusPageChecksCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChanges("checks", "check_aliases") {
+		return nil
+	}
+
+	key, values := "checks", d.Get("checks").([]interface{})
+	if aliases := d.Get("check_aliases").([]interface{}); len(aliases) > 0 {
+		key, values = "check_aliases", aliases
+	}
+
+	known := map[int]string{}
+	for i, v := range values {
+		if d.NewValueKnown(fmt.Sprintf("%s.%d", key, i)) {
+			known[i] = v.(string)
+		}
+	}
+
+	if len(known) == 0 {
+		return nil
+	}
+
+	errs := []error{duplicatedStatusPageChecks(key, values, known)}
+
+	checks, err := listChecks(ctx, meta.(*providerMeta).client)
+	if err != nil {
+		return fmt.Errorf("reading checks from the API: %w", err)
+	}
+
+	if key == "checks" {
+		errs = append(errs, missingStatusPageChecks(checks, known))
+	} else {
+		_, err := resolveCheckAliases(checks, known)
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// duplicatedStatusPageChecks reports every known value of the list that is a
+// duplicate of a previous one.
+func duplicatedStatusPageChecks(key string, values []interface{}, known map[int]string) error {
+	var errs []error
+	first := map[string]int{}
+	for i := range values {
+		v, ok := known[i]
+		if !ok {
+			continue
+		}
+
+		if j, ok := first[v]; ok {
+			errs = append(errs, fmt.Errorf("%s.%d: %q is a duplicate of %s.%d", key, i, v, key, j))
+			continue
+		}
+		first[v] = i
+	}
+	return errors.Join(errs...)
+}
+
+// missingStatusPageChecks reports the tokens which don't match any check.
+func missingStatusPageChecks(checks []updown.Check, tokens map[int]string) error {
+	existing := map[string]bool{}
+	for _, check := range checks {
+		existing[check.Token] = true
+	}
+
+	var errs []error
+	for _, i := range sortedKeys(tokens) {
+		if !existing[tokens[i]] {
+			errs = append(errs, fmt.Errorf("checks.%d: no check found with token %q", i, tokens[i]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// resolveCheckAliases returns the tokens of the checks matching the aliases,
+// indexed like them, with an error for every alias matching none or several
+// checks.
+func resolveCheckAliases(checks []updown.Check, aliases map[int]string) (map[int]string, error) {
+	tokens := map[string][]string{}
+	for _, check := range checks {
+		tokens[check.Alias] = append(tokens[check.Alias], check.Token)
+	}
+
+	var errs []error
+	resolved := map[int]string{}
+	for _, i := range sortedKeys(aliases) {
+		switch matches := tokens[aliases[i]]; len(matches) {
+		case 0:
+			errs = append(errs, fmt.Errorf("check_aliases.%d: no check found with alias %q", i, aliases[i]))
+		case 1:
+			resolved[i] = matches[0]
+		default:
+			errs = append(errs, fmt.Errorf("check_aliases.%d: alias %q is shared by several checks (%s)", i, aliases[i], strings.Join(matches, ", ")))
+		}
+	}
+	return resolved, errors.Join(errs...)
+}
+
+// statusPageCheckTokens returns the tokens of the checks of the list, which
+// are check aliases when useAliases is set.
+func statusPageCheckTokens(ctx context.Context, client *updown.Client, values []string, useAliases bool) ([]string, error) {
+	if !useAliases {
+		return append([]string{}, values...), nil
+	}
+
+	checks, err := listChecks(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("reading checks from the API: %w", err)
+	}
+
+	aliases := map[int]string{}
+	for i, alias := range values {
+		aliases[i] = alias
+	}
+
+	resolved, err := resolveCheckAliases(checks, aliases)
+	tokens := []string{}
+	for _, i := range sortedKeys(resolved) {
+		tokens = append(tokens, resolved[i])
+	}
+	return tokens, err
+}
+
+// statusPageAccessKeyCustomizeDiff makes sure new protected pages have an
+// access key configured, or generated through access_key_rotation, and plans
+// a new one on rotation.
+func statusPageAccessKeyCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() {
+		return nil
+	}
+
+	// Configured keys are always available, the write-only one is just never
+	// known from the state
+	if !config.GetAttr("access_key").IsNull() || !config.GetAttr("access_key_wo").IsNull() {
+		return nil
+	}
+
+	if !d.NewValueKnown("visibility") || d.Get("visibility").(string) != "protected" {
+		return nil
+	}
+
+	// Existing pages keep the key of the state, e.g. generated by the API or
+	// imported, and get one generated on apply when it's missing
+	if config.GetAttr("access_key_rotation").IsNull() && d.Id() == "" {
+		return errors.New("access_key: protected status pages require access_key, access_key_wo or access_key_rotation to generate one")
+	}
+
+	if d.Id() != "" && (d.HasChange("access_key_rotation") || d.Get("access_key").(string) == "") {
+		return d.SetNewComputed("access_key")
+	}
+
+	return nil
 }
 
-func statusPageCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*updown.Client)
+func statusPageCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	if shouldAdoptExisting(d, meta) {
+		statusPages, err := listStatusPages(ctx, client)
+		if err != nil {
+			return diag.Errorf("reading status pages from the API: %s", err)
+		}
+
+		statusPage, found, err := findAdoptableStatusPage(statusPages, d.Get("name").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if found {
+			d.SetId(statusPage.Token)
+			if diags := statusPageUpdate(ctx, d, meta); diags.HasError() {
+				return diags
+			}
+			return adoptedWarning("status page", statusPage.Token, statusPage.Name)
+		}
+	}
+
+	item, err := constructStatusPagePayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	payload := statusPagePayload{StatusPageItem: item}
+	if aliases := listToStringSlice(d.Get("check_aliases").([]interface{})); len(aliases) > 0 {
+		checks, err := statusPageCheckTokens(ctx, client, aliases, true)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		payload.StatusPageItem.Checks = checks
+	}
+	if checks := payload.StatusPageItem.Checks; len(checks) > 0 {
+		payload.Checks = &checks
+	}
 
-	statusPage, _, err := client.StatusPage.Add(constructStatusPagePayload(d))
+	statusPage, err := addStatusPage(ctx, client, payload)
 	if err != nil {
-		return fmt.Errorf("creating status page with the API: %w", err)
+		return diag.Errorf("creating status page with the API: %s", err)
 	}
 
 	d.SetId(statusPage.Token)
 
-	return statusPageRead(d, meta)
+	return statusPageRead(ctx, d, meta)
 }
 
-func statusPageRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*updown.Client)
-	statusPage, _, err := client.StatusPage.Get(d.Id())
+func statusPageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	statusPage, found, err := findStatusPage(ctx, client, d.Id())
 
 	if err != nil {
-		return fmt.Errorf("reading status page from the API: %w", err)
+		return diag.Errorf("reading status page from the API: %s", err)
+	}
+
+	// Deleted outside of Terraform, it's planned to be created again
+	if !found {
+		d.SetId("")
+		return nil
+	}
+
+	return diag.FromErr(setStatusPageData(ctx, d, meta, statusPage))
+}
+
+// setStatusPageData stores the status page returned by the API in the
+// resource data.
+func setStatusPageData(ctx context.Context, d *schema.ResourceData, meta interface{}, statusPage updown.StatusPage) error {
+	client := meta.(*providerMeta).client
+
+	// Checks configured through their aliases are tracked the same way,
+	// keeping the token of the ones without an alias to surface the drift
+	checksKey, checks := "checks", statusPage.Checks
+	if len(d.Get("check_aliases").([]interface{})) > 0 {
+		allChecks, err := listChecks(ctx, client)
+		if err != nil {
+			return fmt.Errorf("reading checks from the API: %w", err)
+		}
+
+		aliases := map[string]string{}
+		for _, check := range allChecks {
+			if check.Alias != "" {
+				aliases[check.Token] = check.Alias
+			}
+		}
+
+		checksKey, checks = "check_aliases", nil
+		for _, token := range statusPage.Checks {
+			if alias, ok := aliases[token]; ok {
+				checks = append(checks, alias)
+			} else {
+				checks = append(checks, token)
+			}
+		}
+	}
+
+	// Only the checks managed by this resource are tracked in additive mode,
+	// in the configured order as it isn't enforced
+	checksMode := d.Get("checks_mode").(string)
+	if checksMode == "" {
+		checksMode = "authoritative" // Not set on import
+	}
+	if checksMode == "additive" {
+		shown := map[string]bool{}
+		for _, check := range checks {
+			shown[check] = true
+		}
+
+		checks = nil
+		for _, check := range d.Get(checksKey).([]interface{}) {
+			if shown[check.(string)] {
+				checks = append(checks, check.(string))
+			}
+		}
+	}
+
+	if err := d.Set(checksKey, checks); err != nil {
+		return err
+	}
+
+	// Keys set through access_key_wo are kept out of the state
+	accessKey := statusPage.AccessKey
+	if _, ok := d.GetOk("access_key_wo_version"); ok {
+		accessKey = ""
 	}
 
 	for k, v := range map[string]interface{}{
-		"checks":      statusPage.Checks,
+		"checks_mode": checksMode,
 		"name":        statusPage.Name,
 		"description": statusPage.Description,
 		"visibility":  statusPage.Visibility,
-		"access_key":  statusPage.AccessKey,
+		"access_key":  accessKey,
 		"url":         statusPage.URL,
 	} {
 		if err := d.Set(k, v); err != nil {
@@ -130,36 +486,85 @@ func statusPageRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	return nil
+	return setIDIdentity(d, "token")
 }
 
-func statusPageUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*updown.Client)
+func statusPageUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+
+	// updown_status_page_check resources update the same checks list
+	resourceLocks.Lock(statusPageLockKey(d.Id()))
+	defer resourceLocks.Unlock(statusPageLockKey(d.Id()))
+
+	item, err := constructStatusPagePayload(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	payload := statusPagePayload{StatusPageItem: item}
+	aliases := listToStringSlice(d.Get("check_aliases").([]interface{}))
+	checks, err := statusPageCheckTokens(ctx, client, append(payload.StatusPageItem.Checks, aliases...), len(aliases) > 0)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The checks added elsewhere are kept in additive mode
+	if d.Get("checks_mode").(string) == "additive" {
+		statusPage, err := getStatusPage(ctx, client, d.Id())
+		if err != nil {
+			return diag.Errorf("reading status page from the API: %s", err)
+		}
+
+		// Checks whose alias changed since can't be resolved anymore, they
+		// are left alone
+		oldChecks, _ := d.GetChange("checks")
+		oldAliases, _ := d.GetChange("check_aliases")
+		previous, _ := statusPageCheckTokens(ctx, client, append(listToStringSlice(oldChecks.([]interface{})), listToStringSlice(oldAliases.([]interface{}))...), len(oldAliases.([]interface{})) > 0)
+
+		checks = additiveStatusPageChecks(statusPage.Checks, previous, checks)
+	}
+	payload.Checks = &checks
 
-	_, _, err := client.StatusPage.Update(d.Id(), constructStatusPagePayload(d))
+	_, err = updateStatusPage(ctx, client, d.Id(), payload)
 	if err != nil {
-		return fmt.Errorf("updating status page with the API: %w", err)
+		return diag.Errorf("updating status page with the API: %s", err)
 	}
 
-	return statusPageRead(d, meta)
+	return statusPageRead(ctx, d, meta)
 }
 
-func statusPageDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*updown.Client)
-	deleted, _, err := client.StatusPage.Remove(d.Id())
+// additiveStatusPageChecks keeps the checks shown on the page outside of this
+// resource in place, only removing the managed ones removed from the
+// configuration and appending the missing ones.
+func additiveStatusPageChecks(current, previous, configured []string) []string {
+	removed := map[string]bool{}
+	for _, token := range previous {
+		removed[token] = true
+	}
+	for _, token := range configured {
+		delete(removed, token)
+	}
+
+	checks := []string{}
+	for _, token := range current {
+		if !removed[token] {
+			checks = append(checks, token)
+		}
+	}
+	return mergeIDs(checks, configured)
+}
+
+func statusPageDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	deleted, err := removeStatusPage(ctx, client, d.Id())
 
 	if err != nil {
-		return fmt.Errorf("removing status page from the API: %w", err)
+		return diag.Errorf("removing status page from the API: %s", err)
 	}
 
 	if !deleted {
-		return fmt.Errorf("status page couldn't be deleted")
+		return diag.Errorf("status page couldn't be deleted")
 	}
 
 	return nil
 }
-
-func statusPageExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	err := statusPageRead(d, meta)
-	return err == nil, err
-}
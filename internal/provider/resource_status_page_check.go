@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func statusPageCheckResource() *schema.Resource {
+	return &schema.Resource{
+		Description: "`updown_status_page_check` shows a check on a status page without managing the page itself. " +
+			"The `updown_status_page` resource should use `checks_mode = \"additive\"` so that both don't fight over the checks.",
+
+		CreateContext: statusPageCheckCreate,
+		ReadContext:   statusPageCheckRead,
+		UpdateContext: statusPageCheckUpdate,
+		DeleteContext: statusPageCheckDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: statusPageCheckImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"status_page": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Token of the status page.",
+			},
+			"check": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Token of the check to show on the page.",
+			},
+			"position": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Position of the check on the page, starting at 0. The check is appended when unset, and positions past the end of the list are appended as well, and kept as long as the check is the last one.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+		},
+	}
+}
+
+func statusPageCheckID(statusPageToken, checkToken string) string {
+	return statusPageToken + "/" + checkToken
+}
+
+func parseStatusPageCheckID(id string) (string, string, error) {
+	statusPageToken, checkToken, found := strings.Cut(id, "/")
+	if !found || statusPageToken == "" || checkToken == "" {
+		return "", "", fmt.Errorf("unexpected ID %q, expected <status_page_token>/<check_token>", id)
+	}
+	return statusPageToken, checkToken, nil
+}
+
+// moveStatusPageCheck returns the checks with the given one moved to the
+// position, or appended when it is negative or past the end of the list.
+func moveStatusPageCheck(checks []string, token string, position int) []string {
+	moved := []string{}
+	for _, t := range checks {
+		if t != token {
+			moved = append(moved, t)
+		}
+	}
+
+	if position < 0 || position >= len(moved) {
+		return append(moved, token)
+	}
+
+	return append(moved[:position], append([]string{token}, moved[position:]...)...)
+}
+
+// statusPageCheckApply shows the check at the configured position on the page,
+// used both on create and update.
+func statusPageCheckApply(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	statusPageToken := d.Get("status_page").(string)
+	checkToken := d.Get("check").(string)
+
+	position := -1
+	if !d.GetRawConfig().GetAttr("position").IsNull() {
+		position = d.Get("position").(int)
+	}
+
+	resourceLocks.Lock(statusPageLockKey(statusPageToken))
+	defer resourceLocks.Unlock(statusPageLockKey(statusPageToken))
+
+	statusPage, found, err := findStatusPage(ctx, client, statusPageToken)
+	if err != nil {
+		return diag.Errorf("reading status page from the API: %s", err)
+	}
+	if !found {
+		return diag.Errorf("status page %s not found", statusPageToken)
+	}
+
+	// Keep the check where it is when no position is requested
+	if position < 0 {
+		for i, t := range statusPage.Checks {
+			if t == checkToken {
+				position = i
+			}
+		}
+	}
+
+	checks := moveStatusPageCheck(statusPage.Checks, checkToken, position)
+	if _, err := updateStatusPage(ctx, client, statusPageToken, statusPagePayload{Checks: &checks}); err != nil {
+		return diag.Errorf("updating status page checks with the API: %s", err)
+	}
+
+	d.SetId(statusPageCheckID(statusPageToken, checkToken))
+
+	return statusPageCheckRead(ctx, d, meta)
+}
+
+func statusPageCheckCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return statusPageCheckApply(ctx, d, meta)
+}
+
+func statusPageCheckUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return statusPageCheckApply(ctx, d, meta)
+}
+
+func statusPageCheckRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	statusPageToken, checkToken, err := parseStatusPageCheckID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	statusPage, found, err := findStatusPage(ctx, client, statusPageToken)
+	if err != nil {
+		return diag.Errorf("reading status page from the API: %s", err)
+	}
+
+	position := -1
+	for i, t := range statusPage.Checks {
+		if t == checkToken {
+			position = i
+		}
+	}
+
+	// Status page deleted or check removed outside of Terraform
+	if !found || position < 0 {
+		d.SetId("")
+		return nil
+	}
+
+	// A position past the end of the list is applied by appending the check,
+	// so it is kept as long as the check is still the last one instead of
+	// drifting to the actual index
+	if last := len(statusPage.Checks) - 1; position == last && d.Get("position").(int) > last {
+		position = d.Get("position").(int)
+	}
+
+	for k, v := range map[string]interface{}{
+		"status_page": statusPageToken,
+		"check":       checkToken,
+		"position":    position,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func statusPageCheckDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*providerMeta).client
+	statusPageToken, checkToken, err := parseStatusPageCheckID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	resourceLocks.Lock(statusPageLockKey(statusPageToken))
+	defer resourceLocks.Unlock(statusPageLockKey(statusPageToken))
+
+	statusPage, found, err := findStatusPage(ctx, client, statusPageToken)
+	if err != nil {
+		return diag.Errorf("reading status page from the API: %s", err)
+	}
+	if !found {
+		return nil
+	}
+
+	checks := []string{}
+	for _, t := range statusPage.Checks {
+		if t != checkToken {
+			checks = append(checks, t)
+		}
+	}
+
+	if len(checks) == len(statusPage.Checks) {
+		return nil
+	}
+
+	if _, err := updateStatusPage(ctx, client, statusPageToken, statusPagePayload{Checks: &checks}); err != nil {
+		return diag.Errorf("updating status page checks with the API: %s", err)
+	}
+
+	return nil
+}
+
+func statusPageCheckImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseStatusPageCheckID(d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
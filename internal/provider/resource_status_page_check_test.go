@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sergo-techhub/updown"
+)
+
+func TestAccUpdownStatusPageCheck_basic(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	resourceName := "updown_status_page_check.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckUpdownStatusPageCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUpdownStatusPageCheckConfig_basic(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUpdownStatusPageCheckExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "position", "1"),
+					resource.TestCheckResourceAttr("updown_status_page.test", "checks.#", "1"),
+				),
+			},
+			{
+				Config: testAccUpdownStatusPageCheckConfig_basic(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUpdownStatusPageCheckExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "position", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestMoveStatusPageCheck(t *testing.T) {
+	for name, tc := range map[string]struct {
+		checks   []string
+		token    string
+		position int
+		expected []string
+	}{
+		"append":          {[]string{"a", "b"}, "c", -1, []string{"a", "b", "c"}},
+		"insert":          {[]string{"a", "b"}, "c", 1, []string{"a", "c", "b"}},
+		"first":           {[]string{"a", "b"}, "c", 0, []string{"c", "a", "b"}},
+		"past the end":    {[]string{"a", "b"}, "c", 10, []string{"a", "b", "c"}},
+		"move":            {[]string{"a", "b", "c"}, "c", 0, []string{"c", "a", "b"}},
+		"already in last": {[]string{"a", "b", "c"}, "c", 2, []string{"a", "b", "c"}},
+		"empty page":      {nil, "a", 3, []string{"a"}},
+	} {
+		if got := moveStatusPageCheck(tc.checks, tc.token, tc.position); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("%s: got %v, expected %v", name, got, tc.expected)
+		}
+	}
+}
+
+func TestParseStatusPageCheckID(t *testing.T) {
+	statusPageToken, checkToken, err := parseStatusPageCheckID(statusPageCheckID("page1", "ab12"))
+	if err != nil || statusPageToken != "page1" || checkToken != "ab12" {
+		t.Errorf("unexpected result: %q, %q, %v", statusPageToken, checkToken, err)
+	}
+
+	if _, _, err := parseStatusPageCheckID("page1"); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestStatusPageCheckRead_positionPastTheEnd(t *testing.T) {
+	client := newTestClient(t, map[string]interface{}{
+		"status_pages": []updown.StatusPage{{Token: "page1", Checks: []string{"a", "b", "c"}}},
+	})
+
+	for name, tc := range map[string]struct {
+		check    string
+		position string
+		expected int
+	}{
+		// Applied as the last check, a position of 5 doesn't drift to 2
+		"last":     {"c", "5", 5},
+		"not last": {"b", "5", 1},
+		"moved":    {"c", "1", 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := statusPageCheckResource()
+			d := r.Data(&terraform.InstanceState{
+				ID:         statusPageCheckID("page1", tc.check),
+				Attributes: map[string]string{"status_page": "page1", "check": tc.check, "position": tc.position},
+			})
+
+			if diags := r.ReadContext(context.Background(), d, &providerMeta{client: client}); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got := d.Get("position").(int); got != tc.expected {
+				t.Errorf("got position %d, expected %d", got, tc.expected)
+			}
+		})
+	}
+}
+
+func testAccCheckUpdownStatusPageCheckDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "updown_status_page_check" {
+			continue
+		}
+	}
+	return nil
+}
+
+func testAccCheckUpdownStatusPageCheckExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("Not found: %s", resourceName)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Status Page Check ID is set")
+		}
+
+		return nil
+	}
+}
+
+func testAccUpdownStatusPageCheckConfig_basic(rName string, position int) string {
+	return fmt.Sprintf(`
+resource "updown_check" "owned" {
+  url   = "https://example.com"
+  alias = "%[1]s-owned"
+}
+
+resource "updown_check" "associated" {
+  url   = "https://example.org"
+  alias = "%[1]s-associated"
+}
+
+resource "updown_status_page" "test" {
+  name        = %[1]q
+  visibility  = "private"
+  checks_mode = "additive"
+  checks      = [updown_check.owned.id]
+}
+
+resource "updown_status_page_check" "test" {
+  status_page = updown_status_page.test.id
+  check       = updown_check.associated.id
+  position    = %[2]d
+}
+`, rName, position)
+}
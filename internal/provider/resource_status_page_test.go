@@ -1,12 +1,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sergo-techhub/updown"
 )
 
 func TestAccUpdownStatusPage_basic(t *testing.T) {
@@ -73,6 +79,124 @@ func TestAccUpdownStatusPage_protected(t *testing.T) {
 	})
 }
 
+func TestAccUpdownStatusPage_protectedGeneratedKey(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	resourceName := "updown_status_page.test"
+	var accessKey string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckUpdownStatusPageDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUpdownStatusPageConfig_rotation(rName, "v1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUpdownStatusPageExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "access_key"),
+					resource.TestCheckResourceAttrWith(resourceName, "access_key", func(value string) error {
+						accessKey = value
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccUpdownStatusPageConfig_rotation(rName, "v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith(resourceName, "access_key", func(value string) error {
+						if value == "" || value == accessKey {
+							return fmt.Errorf("expected access_key to be rotated, got %q", value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestStatusPageResource_emptyAccessKey(t *testing.T) {
+	diags := statusPageResource().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"visibility": "protected",
+		"access_key": "",
+		"checks":     []interface{}{"ab12"},
+	}))
+
+	if !diags.HasError() {
+		t.Error("expected an error for an empty access_key")
+	}
+}
+
+func TestStatusPageAccessKeyCustomizeDiff(t *testing.T) {
+	for name, tc := range map[string]struct {
+		state    map[string]string
+		config   map[string]string
+		expected string
+	}{
+		"protected without key": {
+			config:   map[string]string{"name": "Partners", "visibility": "protected"},
+			expected: "access_key: protected status pages require access_key, access_key_wo or access_key_rotation",
+		},
+		"existing protected with a key in the state": {
+			state:  map[string]string{"id": "abcd", "name": "Partners", "visibility": "protected", "access_key": "generated"},
+			config: map[string]string{"name": "Partners", "visibility": "protected"},
+		},
+		"existing protected without key": {
+			state:  map[string]string{"id": "abcd", "name": "Partners", "visibility": "public"},
+			config: map[string]string{"name": "Partners", "visibility": "protected"},
+		},
+		"protected with key": {
+			config: map[string]string{"name": "Partners", "visibility": "protected", "access_key": "secret"},
+		},
+		"protected with generated key": {
+			config: map[string]string{"name": "Partners", "visibility": "protected", "access_key_rotation": "v1"},
+		},
+		"public": {
+			config: map[string]string{"name": "Partners"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := statusPageResource()
+
+			// The raw configuration is only passed along with the state
+			config := map[string]interface{}{}
+			attributes := map[string]cty.Value{}
+			for k, ty := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+				attributes[k] = cty.NullVal(ty)
+			}
+			for k, v := range tc.config {
+				config[k] = v
+				attributes[k] = cty.StringVal(v)
+			}
+			state := &terraform.InstanceState{ID: tc.state["id"], Attributes: tc.state, RawConfig: cty.ObjectVal(attributes)}
+
+			_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), &providerMeta{})
+			switch {
+			case tc.expected == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case tc.expected != "" && (err == nil || !strings.Contains(err.Error(), tc.expected)):
+				t.Errorf("expected error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestGenerateAccessKey(t *testing.T) {
+	first, err := generateAccessKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	second, err := generateAccessKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(first) != 32 || first == second {
+		t.Errorf("unexpected access keys: %q and %q", first, second)
+	}
+}
+
 func TestAccUpdownStatusPage_update(t *testing.T) {
 	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
 	rNameUpdated := rName + "-updated"
@@ -101,6 +225,116 @@ func TestAccUpdownStatusPage_update(t *testing.T) {
 	})
 }
 
+func TestAdditiveStatusPageChecks(t *testing.T) {
+	checks := additiveStatusPageChecks(
+		[]string{"a", "x", "b", "y"}, // shown on the page
+		[]string{"a", "b"},           // previously managed
+		[]string{"b", "c"},           // configured
+	)
+
+	if expected := []string{"x", "b", "y", "c"}; !reflect.DeepEqual(checks, expected) {
+		t.Errorf("got %v, expected %v", checks, expected)
+	}
+}
+
+func TestAccUpdownStatusPage_checkAliases(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	resourceName := "updown_status_page.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckUpdownStatusPageDestroy,
+		Steps: []resource.TestStep{
+			// Aliases are resolved at plan time, the checks have to exist first
+			{
+				Config: testAccUpdownStatusPageConfig_checkAliases(rName, false),
+			},
+			{
+				Config: testAccUpdownStatusPageConfig_checkAliases(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUpdownStatusPageExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "check_aliases.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "check_aliases.0", rName+"-b"),
+					resource.TestCheckResourceAttr(resourceName, "check_aliases.1", rName+"-a"),
+					resource.TestCheckResourceAttr(resourceName, "checks.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUpdownStatusPage_unknownCheck(t *testing.T) {
+	rName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckUpdownStatusPageDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUpdownStatusPageConfig_unknownCheck(rName),
+				ExpectError: regexp.MustCompile(`checks.0: no check found with token "tf-test-unknown"`),
+			},
+		},
+	})
+}
+
+func TestDuplicatedStatusPageChecks(t *testing.T) {
+	values := []interface{}{"a", "b", "a", "c", "b", "a"}
+	known := map[int]string{0: "a", 1: "b", 2: "a", 3: "c", 5: "a"}
+
+	err := duplicatedStatusPageChecks("checks", values, known)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	for _, expected := range []string{`checks.2: "a" is a duplicate of checks.0`, `checks.5: "a" is a duplicate of checks.0`} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q in %q", expected, err)
+		}
+	}
+	if strings.Contains(err.Error(), "checks.4") {
+		t.Errorf("unexpected error for an unknown value in %q", err)
+	}
+
+	if err := duplicatedStatusPageChecks("checks", []interface{}{"a", "b"}, map[int]string{0: "a", 1: "b"}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestMissingStatusPageChecks(t *testing.T) {
+	checks := []updown.Check{{Token: "a"}, {Token: "b"}}
+
+	err := missingStatusPageChecks(checks, map[int]string{0: "a", 1: "x", 2: "b"})
+	if err == nil || err.Error() != `checks.1: no check found with token "x"` {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestResolveCheckAliases(t *testing.T) {
+	checks := []updown.Check{
+		{Token: "a", Alias: "website"},
+		{Token: "b", Alias: "api"},
+		{Token: "c", Alias: "dup"},
+		{Token: "d", Alias: "dup"},
+	}
+
+	tokens, err := resolveCheckAliases(checks, map[int]string{0: "api", 1: "website", 2: "dup", 3: "typo"})
+	if expected := map[int]string{0: "b", 1: "a"}; !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("got %v, expected %v", tokens, expected)
+	}
+
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, expected := range []string{`check_aliases.2: alias "dup" is shared by several checks (c, d)`, `check_aliases.3: no check found with alias "typo"`} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q in %q", expected, err)
+		}
+	}
+}
+
 func testAccCheckUpdownStatusPageDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "updown_status_page" {
@@ -155,6 +389,42 @@ resource "updown_status_page" "test" {
 `, checkName, statusPageName)
 }
 
+func testAccUpdownStatusPageConfig_checkAliases(rName string, withPage bool) string {
+	config := fmt.Sprintf(`
+resource "updown_check" "a" {
+  url   = "https://example.com"
+  alias = "%[1]s-a"
+}
+
+resource "updown_check" "b" {
+  url   = "https://example.org"
+  alias = "%[1]s-b"
+}
+`, rName)
+
+	if withPage {
+		config += fmt.Sprintf(`
+resource "updown_status_page" "test" {
+  name          = %[1]q
+  visibility    = "private"
+  check_aliases = ["%[1]s-b", "%[1]s-a"]
+}
+`, rName)
+	}
+
+	return config
+}
+
+func testAccUpdownStatusPageConfig_unknownCheck(rName string) string {
+	return fmt.Sprintf(`
+resource "updown_status_page" "test" {
+  name       = %[1]q
+  visibility = "private"
+  checks     = ["tf-test-unknown"]
+}
+`, rName)
+}
+
 func testAccUpdownStatusPageConfig_public(rName string) string {
 	return fmt.Sprintf(`
 resource "updown_check" "test" {
@@ -171,6 +441,22 @@ resource "updown_status_page" "test" {
 `, rName)
 }
 
+func testAccUpdownStatusPageConfig_rotation(rName, rotation string) string {
+	return fmt.Sprintf(`
+resource "updown_check" "test" {
+  url   = "https://example.com"
+  alias = "%[1]s-check"
+}
+
+resource "updown_status_page" "test" {
+  name                = %[1]q
+  visibility          = "protected"
+  access_key_rotation = %[2]q
+  checks              = [updown_check.test.id]
+}
+`, rName, rotation)
+}
+
 func testAccUpdownStatusPageConfig_protected(rName string) string {
 	return fmt.Sprintf(`
 resource "updown_check" "test" {
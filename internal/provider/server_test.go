@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+func TestProviderServer(t *testing.T) {
+	ctx := context.Background()
+
+	serverFactory, err := NewProviderServer(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The provider schemas of the SDK and framework providers must be
+	// identical, which is only checked when muxing them
+	resp, err := serverFactory().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, diag := range resp.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", diag.Summary, diag.Detail)
+	}
+
+	for _, name := range []string{"updown_check", "updown_recipient", "updown_status_page"} {
+		if _, ok := resp.ResourceSchemas[name]; !ok {
+			t.Errorf("missing resource schema %s", name)
+		}
+		if _, ok := resp.ListResourceSchemas[name]; !ok {
+			t.Errorf("missing list resource schema %s", name)
+		}
+	}
+
+	if _, ok := resp.ResourceSchemas["updown_maintenance_window"]; !ok {
+		t.Error("missing resource schema updown_maintenance_window")
+	}
+
+	for _, name := range []string{"updown_mute_check", "updown_set_check_enabled", "updown_unmute_check"} {
+		if _, ok := resp.ActionSchemas[name]; !ok {
+			t.Errorf("missing action schema %s", name)
+		}
+	}
+
+	for _, name := range []string{"check_type", "mute_until", "normalize_url", "period"} {
+		if _, ok := resp.Functions[name]; !ok {
+			t.Errorf("missing function %s", name)
+		}
+	}
+}
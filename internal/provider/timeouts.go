@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// timeoutOperations are the verbs naming the operations of the timeouts block
+// in the errors.
+var timeoutOperations = map[string]string{
+	schema.TimeoutCreate: "creating",
+	schema.TimeoutRead:   "reading",
+	schema.TimeoutUpdate: "updating",
+	schema.TimeoutDelete: "deleting",
+}
+
+// withTimeout replaces the error returned by f once the timeout of the
+// operation is reached, which otherwise only reads "context deadline
+// exceeded", by one naming the operation and the resource.
+func withTimeout(operation, resource string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := f(ctx, d, meta)
+		if !diags.HasError() || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return diags
+		}
+
+		return timeoutDiagnostics(operation, resource, d, diags)
+	}
+}
+
+// timeoutDiagnostics keeps the warnings of diags, the errors being detailed
+// under the timeout one.
+func timeoutDiagnostics(operation, resource string, d *schema.ResourceData, diags diag.Diagnostics) diag.Diagnostics {
+	name := resource
+	if d.Id() != "" {
+		name += " " + d.Id()
+	}
+
+	var details []string
+	var timeoutDiags diag.Diagnostics
+	for _, diagnostic := range diags {
+		if diagnostic.Severity != diag.Error {
+			timeoutDiags = append(timeoutDiags, diagnostic)
+			continue
+		}
+		details = append(details, diagnostic.Summary)
+	}
+
+	return append(timeoutDiags, diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("timeout while %s %s after %s", timeoutOperations[operation], name, d.Timeout(operation)),
+		Detail:   fmt.Sprintf("%s\n\nThe timeout can be raised with the %s argument of the timeouts block.", strings.Join(details, "\n"), operation),
+	})
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestWithTimeout(t *testing.T) {
+	f := withTimeout(schema.TimeoutUpdate, "status page", func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+		return append(diag.Diagnostics{{Severity: diag.Warning, Summary: "adopted"}}, diag.Errorf("updating status page with the API: context deadline exceeded")...)
+	})
+
+	d := statusPageResource().TestResourceData()
+	d.SetId("abcd")
+
+	// Errors unrelated to the timeout are kept as is
+	diags := f(context.Background(), d, nil)
+	if len(diags) != 2 || diags[1].Summary != "updating status page with the API: context deadline exceeded" {
+		t.Errorf("unexpected diagnostics %#v", diags)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	diags = f(ctx, d, nil)
+	if len(diags) != 2 || diags[0].Severity != diag.Warning {
+		t.Fatalf("unexpected diagnostics %#v", diags)
+	}
+	if expected := "timeout while updating status page abcd after "; !strings.HasPrefix(diags[1].Summary, expected) {
+		t.Errorf("got %q, expected %q", diags[1].Summary, expected)
+	}
+	if !strings.Contains(diags[1].Detail, "updating status page with the API") {
+		t.Errorf("expected the error in the detail, got %q", diags[1].Detail)
+	}
+}
+
+func TestCheckRead_timeout(t *testing.T) {
+	client := newHandlerTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	d := checkResource().TestResourceData()
+	d.SetId("abcd")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	diags := checkResource().ReadContext(ctx, d, &providerMeta{client: client})
+	if !diags.HasError() || !strings.HasPrefix(diags[0].Summary, "timeout while reading check abcd after ") {
+		t.Errorf("unexpected diagnostics %#v", diags)
+	}
+}
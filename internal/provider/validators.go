@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var hostnameRegexp = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.?$`)
+
+// e164Regexp matches phone numbers in the E.164 format, without the spaces
+// and dashes the API strips.
+var e164Regexp = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// phoneNumberSeparators are stripped from phone numbers by the API.
+var phoneNumberSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// privateHostSuffixes are reserved or conventional suffixes that never resolve
+// on the public internet.
+var privateHostSuffixes = []string{
+	".localhost",
+	".local",
+	".internal",
+	".lan",
+	".localdomain",
+	".home.arpa",
+	".invalid",
+	".test",
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which is not
+// covered by net.IP.IsPrivate.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// checkPeriods are the intervals between checks supported by the API, in
+// seconds.
+var checkPeriods = []int{15, 30, 60, 120, 300, 600, 1800, 3600}
+
+// closestCheckPeriod returns the shortest supported period not checking more
+// often than the interval, or the longest one for longer intervals.
+func closestCheckPeriod(seconds int) int {
+	for _, period := range checkPeriods {
+		if period >= seconds {
+			return period
+		}
+	}
+	return checkPeriods[len(checkPeriods)-1]
+}
+
+// parseMuteDuration parses a relative mute duration such as 45m or +2h.
+func parseMuteDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(strings.TrimPrefix(value, "+"))
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q, expected a positive value such as 45m or +2h", value)
+	}
+	return d, nil
+}
+
+// validateMuteDuration is the ValidateFunc of mute_for.
+func validateMuteDuration(v interface{}, k string) ([]string, []error) {
+	if _, err := parseMuteDuration(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	return nil, nil
+}
+
+// validateMuteUntil is the ValidateFunc of mute_until, only durations
+// relative to the time of apply being checked as the API accepts many time
+// formats.
+func validateMuteUntil(v interface{}, k string) ([]string, []error) {
+	if strings.HasPrefix(v.(string), "+") {
+		return validateMuteDuration(v, k)
+	}
+	return nil, nil
+}
+
+// inferCheckType returns the check type matching the scheme of the URL, or an
+// empty string when it has none.
+func inferCheckType(rawURL string) string {
+	scheme, _, found := strings.Cut(rawURL, "://")
+	if !found {
+		return ""
+	}
+
+	switch scheme = strings.ToLower(scheme); scheme {
+	case "http", "https", "icmp", "tcp", "tcps":
+		return scheme
+	}
+
+	return ""
+}
+
+// isHTTPCheckType tells whether the check type supports HTTP specific
+// attributes. An empty type is what the API defaults to, which is HTTP.
+func isHTTPCheckType(checkType string) bool {
+	return checkType == "" || checkType == "http" || checkType == "https"
+}
+
+// isHostnameOrIP tells whether the value is a literal IP address or a
+// syntactically valid hostname.
+func isHostnameOrIP(value string) bool {
+	if net.ParseIP(strings.Trim(value, "[]")) != nil {
+		return true
+	}
+
+	return len(value) <= 253 && hostnameRegexp.MatchString(value)
+}
+
+// checkTargetHost extracts the host the check points at, whether the URL has a
+// scheme or is a bare host as used by icmp checks.
+func checkTargetHost(rawURL string) string {
+	if _, rest, found := strings.Cut(rawURL, "://"); found {
+		// Unbracketed IPv6 addresses as used by icmp checks aren't valid URLs
+		if net.ParseIP(rest) != nil {
+			return rest
+		}
+
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return ""
+		}
+		return u.Hostname()
+	}
+
+	if host, _, err := net.SplitHostPort(rawURL); err == nil {
+		return host
+	}
+
+	return strings.Trim(rawURL, "[]")
+}
+
+// nonRoutableReason classifies the target of the check without any DNS lookup
+// and describes why updown.io nodes cannot reach it. It returns an empty
+// string for targets that look publicly routable.
+func nonRoutableReason(rawURL string) string {
+	host := strings.TrimSuffix(strings.ToLower(checkTargetHost(rawURL)), ".")
+	if host == "" {
+		return ""
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		switch {
+		case ip.IsLoopback():
+			return "a loopback address"
+		case ip.IsPrivate():
+			return "a private address"
+		case ip.IsLinkLocalUnicast():
+			return "a link-local address"
+		case ip.IsUnspecified():
+			return "an unspecified address"
+		case ip.IsMulticast():
+			return "a multicast address"
+		case sharedAddressSpace.Contains(ip):
+			return "a shared (carrier-grade NAT) address"
+		}
+		return ""
+	}
+
+	if host == "localhost" {
+		return "a loopback hostname"
+	}
+
+	for _, suffix := range privateHostSuffixes {
+		if strings.HasSuffix(host, suffix) {
+			return "a hostname under the private " + suffix + " suffix"
+		}
+	}
+
+	if !strings.Contains(host, ".") {
+		return "a single label hostname"
+	}
+
+	return ""
+}
+
+// normalizeRecipientValue returns the value the way the API stores it for the
+// recipient type, so that formatting differences don't show as a diff.
+func normalizeRecipientValue(recipientType, value string) string {
+	value = strings.TrimSpace(value)
+
+	switch recipientType {
+	case "email":
+		return strings.ToLower(value)
+	case "sms":
+		return phoneNumberSeparators.Replace(value)
+	case "webhook", "slack_compatible":
+		u, err := url.Parse(value)
+		if err != nil {
+			return value
+		}
+		u.Scheme = strings.ToLower(u.Scheme)
+		u.Host = strings.ToLower(u.Host)
+		return u.String()
+	}
+
+	return value
+}
+
+// validateRecipientValue tells why the value isn't usable for the recipient
+// type, or returns nil.
+func validateRecipientValue(recipientType, value string) error {
+	switch recipientType {
+	case "email":
+		address, err := mail.ParseAddress(value)
+		if err != nil || address.Address != value {
+			return errors.New("must be a valid email address, without display name")
+		}
+	case "sms":
+		if !e164Regexp.MatchString(normalizeRecipientValue(recipientType, value)) {
+			return errors.New("must be a phone number in the E.164 format, e.g. +33612345678")
+		}
+	case "webhook", "slack_compatible":
+		u, err := url.Parse(value)
+		if err != nil || !strings.EqualFold(u.Scheme, "https") || u.Host == "" {
+			return errors.New("must be an absolute https URL")
+		}
+	}
+
+	return nil
+}
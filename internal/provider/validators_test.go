@@ -0,0 +1,132 @@
+package provider
+
+import "testing"
+
+func TestInferCheckType(t *testing.T) {
+	for rawURL, expected := range map[string]string{
+		"https://example.com":  "https",
+		"HTTP://example.com":   "http",
+		"tcp://example.com:22": "tcp",
+		"tcps://example.com:1": "tcps",
+		"icmp://8.8.8.8":       "icmp",
+		"8.8.8.8":              "",
+		"ftp://example.com":    "",
+	} {
+		if got := inferCheckType(rawURL); got != expected {
+			t.Errorf("inferCheckType(%q) = %q, expected %q", rawURL, got, expected)
+		}
+	}
+}
+
+func TestIsHostnameOrIP(t *testing.T) {
+	for value, expected := range map[string]bool{
+		"8.8.8.8":              true,
+		"2001:4860:4860::8888": true,
+		"[::1]":                true,
+		"example.com":          true,
+		"my-host":              true,
+		"https://example.com":  false,
+		"example.com/path":     false,
+		"-invalid.com":         false,
+		"":                     false,
+	} {
+		if got := isHostnameOrIP(value); got != expected {
+			t.Errorf("isHostnameOrIP(%q) = %t, expected %t", value, got, expected)
+		}
+	}
+}
+
+func TestNonRoutableReason(t *testing.T) {
+	for rawURL, routable := range map[string]bool{
+		"https://example.com/health":    true,
+		"8.8.8.8":                       true,
+		"tcp://example.com:5432":        true,
+		"icmp://2001:4860:4860::8888":   true,
+		"http://localhost:8080":         false,
+		"http://127.0.0.1":              false,
+		"10.1.2.3":                      false,
+		"tcp://192.168.1.10:5432":       false,
+		"https://172.16.0.1":            false,
+		"100.64.1.1":                    false,
+		"https://[fe80::1]/":            false,
+		"fd00::1":                       false,
+		"https://api.internal/health":   false,
+		"https://printer.local":         false,
+		"tcp://db.corp.home.arpa:5432":  false,
+		"https://intranet/status":       false,
+		"https://EXAMPLE.INTERNAL./foo": false,
+	} {
+		if reason := nonRoutableReason(rawURL); (reason == "") != routable {
+			t.Errorf("nonRoutableReason(%q) = %q, expected routable to be %t", rawURL, reason, routable)
+		}
+	}
+}
+
+func TestNormalizeRecipientValue(t *testing.T) {
+	for _, tc := range []struct {
+		recipientType, value, expected string
+	}{
+		{"email", " Foo@Example.COM ", "foo@example.com"},
+		{"sms", "+33 6 12-34-56.78", "+33612345678"},
+		{"sms", "+1 (555) 123-4567", "+15551234567"},
+		{"webhook", "HTTPS://Example.com/Hook", "https://example.com/Hook"},
+		{"slack_compatible", "https://hooks.slack.com/services/T/B/X", "https://hooks.slack.com/services/T/B/X"},
+	} {
+		if got := normalizeRecipientValue(tc.recipientType, tc.value); got != tc.expected {
+			t.Errorf("normalizeRecipientValue(%q, %q) = %q, expected %q", tc.recipientType, tc.value, got, tc.expected)
+		}
+	}
+}
+
+func TestValidateRecipientValue(t *testing.T) {
+	for _, tc := range []struct {
+		recipientType, value string
+		valid                bool
+	}{
+		{"email", "foo@example.com", true},
+		{"email", "foo.bar+tag@sub.example.com", true},
+		{"email", "Foo <foo@example.com>", false},
+		{"email", "foo", false},
+		{"email", "foo@", false},
+		{"sms", "+33612345678", true},
+		{"sms", "+33 6 12 34 56 78", true},
+		{"sms", "+1-555-123-4567", true},
+		{"sms", "0612345678", false},
+		{"sms", "+0612345678", false},
+		{"sms", "+1234567890123456", false},
+		{"webhook", "https://example.com/hook", true},
+		{"webhook", "http://example.com/hook", false},
+		{"webhook", "/hook", false},
+		{"slack_compatible", "https://hooks.slack.com/services/T/B/X", true},
+		{"slack_compatible", "hooks.slack.com/services/T/B/X", false},
+	} {
+		if err := validateRecipientValue(tc.recipientType, tc.value); (err == nil) != tc.valid {
+			t.Errorf("validateRecipientValue(%q, %q) = %v, expected valid = %t", tc.recipientType, tc.value, err, tc.valid)
+		}
+	}
+}
+
+func TestValidateMuteUntil(t *testing.T) {
+	for value, valid := range map[string]bool{
+		"2024-05-01T10:00:00Z": true,
+		"tomorrow":             true,
+		"recovery":             true,
+		"+45m":                 true,
+		"+1h30m":               true,
+		"+45":                  false,
+		"+-1h":                 false,
+		"+soon":                false,
+	} {
+		if _, errs := validateMuteUntil(value, "mute_until"); (len(errs) == 0) != valid {
+			t.Errorf("validateMuteUntil(%q) = %v, expected valid to be %t", value, errs, valid)
+		}
+	}
+}
+
+func TestClosestCheckPeriod(t *testing.T) {
+	for seconds, expected := range map[int]int{1: 15, 15: 15, 45: 60, 300: 300, 900: 1800, 86400: 3600} {
+		if got := closestCheckPeriod(seconds); got != expected {
+			t.Errorf("got %d for %ds, expected %d", got, seconds, expected)
+		}
+	}
+}
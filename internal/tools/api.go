@@ -0,0 +1,25 @@
+package tools
+
+import (
+	"github.com/sergo-techhub/updown"
+)
+
+// checkPayload overrides the recipients of updown.CheckItem, which are omitted
+// when empty, so that a check without recipients can be created.
+type checkPayload struct {
+	updown.CheckItem
+	RecipientIDs []string `json:"recipients"`
+}
+
+// addCheck is the equivalent of client.Check.Add, the check only being
+// attached to the recipients.
+func addCheck(client *updown.Client, item updown.CheckItem, recipients []string) (updown.Check, error) {
+	req, err := client.NewRequest("POST", "checks", checkPayload{CheckItem: item, RecipientIDs: recipients})
+	if err != nil {
+		return updown.Check{}, err
+	}
+
+	var check updown.Check
+	_, err = client.Do(req, &check)
+	return check, err
+}
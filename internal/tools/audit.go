@@ -0,0 +1,340 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sergo-techhub/terraform-provider-updown/internal/provider"
+	"github.com/sergo-techhub/updown"
+)
+
+// AuditReport lists the differences between a Terraform state and the
+// account.
+type AuditReport struct {
+	// Unmanaged are the objects of the account missing from the state.
+	Unmanaged []AuditObject `json:"unmanaged"`
+
+	// Deleted are the objects of the state deleted out of band.
+	Deleted []AuditObject `json:"deleted"`
+
+	// Drift are the attributes changed out of band.
+	Drift []AuditDrift `json:"drift"`
+
+	// MissingRecipients are the recipients of checks which no longer exist.
+	MissingRecipients []AuditMissingRecipient `json:"missing_recipients"`
+}
+
+// AuditObject is an object of the account, along with its resource address
+// when it's in the state.
+type AuditObject struct {
+	Type        string `json:"type"`
+	ID          string `json:"id"`
+	Address     string `json:"address,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// AuditDrift is an attribute whose value in the state differs from the one
+// read from the API.
+type AuditDrift struct {
+	Address   string      `json:"address"`
+	ID        string      `json:"id"`
+	Attribute string      `json:"attribute"`
+	State     interface{} `json:"state"`
+	Remote    interface{} `json:"remote"`
+}
+
+// AuditMissingRecipient is a recipient of a check in the state which no
+// longer exists.
+type AuditMissingRecipient struct {
+	Address   string `json:"address"`
+	ID        string `json:"id"`
+	Recipient string `json:"recipient"`
+}
+
+// Empty tells whether the state matches the account.
+func (r AuditReport) Empty() bool {
+	return len(r.Unmanaged) == 0 && len(r.Deleted) == 0 && len(r.Drift) == 0 && len(r.MissingRecipients) == 0
+}
+
+// auditState is the part of a Terraform state (version 4) the audit needs.
+type auditState struct {
+	Version   int `json:"version"`
+	Resources []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			IndexKey   interface{}                `json:"index_key"`
+			Attributes map[string]json.RawMessage `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// auditedResource is an instance of a resource of the state.
+type auditedResource struct {
+	typeName   string
+	address    string
+	attributes map[string]json.RawMessage
+}
+
+// Audit compares a Terraform state with the checks, recipients and status
+// pages of the account. The attributes are read the same way the provider
+// does, so that only actual changes are reported as drift.
+func Audit(client *updown.Client, state []byte) (AuditReport, error) {
+	var s auditState
+	if err := json.Unmarshal(state, &s); err != nil {
+		return AuditReport{}, fmt.Errorf("reading state: %w", err)
+	}
+	if s.Version != 4 {
+		return AuditReport{}, fmt.Errorf("unsupported state version %d, expected 4", s.Version)
+	}
+
+	var resources []auditedResource
+	for _, resource := range s.Resources {
+		if resource.Mode != "managed" {
+			continue
+		}
+
+		for _, instance := range resource.Instances {
+			address := resource.Type + "." + resource.Name
+			if resource.Module != "" {
+				address = resource.Module + "." + address
+			}
+			switch key := instance.IndexKey.(type) {
+			case string:
+				address += fmt.Sprintf("[%q]", key)
+			case float64:
+				address += fmt.Sprintf("[%d]", int(key))
+			}
+
+			resources = append(resources, auditedResource{typeName: resource.Type, address: address, attributes: instance.Attributes})
+		}
+	}
+
+	checks, _, err := client.Check.List()
+	if err != nil {
+		return AuditReport{}, fmt.Errorf("reading checks from the API: %w", err)
+	}
+
+	recipients, _, err := client.Recipient.List()
+	if err != nil {
+		return AuditReport{}, fmt.Errorf("reading recipients from the API: %w", err)
+	}
+
+	statusPages, _, err := client.StatusPage.List()
+	if err != nil {
+		return AuditReport{}, fmt.Errorf("reading status pages from the API: %w", err)
+	}
+
+	return auditResources(client, resources, checks, recipients, statusPages)
+}
+
+func auditResources(client *updown.Client, resources []auditedResource, checks []updown.Check, recipients []updown.Recipient, statusPages []updown.StatusPage) (AuditReport, error) {
+	// Empty lists rather than null in JSON
+	report := AuditReport{
+		Unmanaged:         []AuditObject{},
+		Deleted:           []AuditObject{},
+		Drift:             []AuditDrift{},
+		MissingRecipients: []AuditMissingRecipient{},
+	}
+
+	remote := map[string]map[string]func(*schema.ResourceData) error{
+		"updown_check":       {},
+		"updown_recipient":   {},
+		"updown_status_page": {},
+	}
+	descriptions := map[string]map[string]string{
+		"updown_check":       {},
+		"updown_recipient":   {},
+		"updown_status_page": {},
+	}
+	for _, check := range checks {
+		remote["updown_check"][check.Token] = func(d *schema.ResourceData) error {
+			return provider.SetCheckData(context.Background(), client, d, check)
+		}
+		descriptions["updown_check"][check.Token] = provider.CheckDisplayURL(check.URL)
+	}
+	for _, r := range recipients {
+		// The other types can't be managed by Terraform
+		if slices.Contains(provider.ManagedRecipientTypes(), string(r.Type)) {
+			remote["updown_recipient"][r.ID] = func(d *schema.ResourceData) error { return provider.SetRecipientData(d, r) }
+			descriptions["updown_recipient"][r.ID] = r.Name
+		}
+	}
+	for _, statusPage := range statusPages {
+		remote["updown_status_page"][statusPage.Token] = func(d *schema.ResourceData) error {
+			return provider.SetStatusPageData(context.Background(), client, d, statusPage)
+		}
+		descriptions["updown_status_page"][statusPage.Token] = statusPage.Name
+	}
+
+	resourceFuncs := map[string]func() *schema.Resource{
+		"updown_check":       provider.CheckResource,
+		"updown_recipient":   provider.RecipientResource,
+		"updown_status_page": provider.StatusPageResource,
+	}
+
+	recipientIDs := map[string]bool{}
+	for _, r := range recipients {
+		recipientIDs[r.ID] = true
+	}
+
+	managed := map[string]map[string]bool{}
+	for _, resource := range resources {
+		resourceFunc, ok := resourceFuncs[resource.typeName]
+		if !ok {
+			continue
+		}
+
+		d, err := auditResourceData(resourceFunc(), resource.attributes)
+		if err != nil {
+			return AuditReport{}, fmt.Errorf("reading %s from the state: %w", resource.address, err)
+		}
+
+		if managed[resource.typeName] == nil {
+			managed[resource.typeName] = map[string]bool{}
+		}
+		managed[resource.typeName][d.Id()] = true
+
+		if resource.typeName == "updown_check" {
+			for _, v := range d.Get("recipients").(*schema.Set).List() {
+				if id := v.(string); !recipientIDs[id] {
+					report.MissingRecipients = append(report.MissingRecipients, AuditMissingRecipient{Address: resource.address, ID: d.Id(), Recipient: id})
+				}
+			}
+		}
+
+		setData, ok := remote[resource.typeName][d.Id()]
+		if !ok {
+			report.Deleted = append(report.Deleted, AuditObject{Type: resource.typeName, ID: d.Id(), Address: resource.address})
+			continue
+		}
+
+		drift, err := auditDrift(resourceFunc(), d, setData)
+		if err != nil {
+			return AuditReport{}, fmt.Errorf("reading %s from the API: %w", resource.address, err)
+		}
+		for _, attribute := range drift {
+			attribute.Address = resource.address
+			report.Drift = append(report.Drift, attribute)
+		}
+	}
+
+	for _, typeName := range []string{"updown_check", "updown_recipient", "updown_status_page"} {
+		ids := make([]string, 0, len(remote[typeName]))
+		for id := range remote[typeName] {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			if !managed[typeName][id] {
+				report.Unmanaged = append(report.Unmanaged, AuditObject{Type: typeName, ID: id, Description: descriptions[typeName][id]})
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// auditResourceData loads the attributes of a resource in the state, ignoring
+// the ones removed from the schema since.
+func auditResourceData(r *schema.Resource, attributes map[string]json.RawMessage) (*schema.ResourceData, error) {
+	known := map[string]json.RawMessage{}
+	for name, value := range attributes {
+		if _, ok := r.Schema[name]; ok || name == "id" {
+			known[name] = value
+		}
+	}
+
+	b, err := json.Marshal(known)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := ctyjson.Unmarshal(b, r.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := r.ShimInstanceStateFromValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Data(state), nil
+}
+
+// auditDrift reads the object from the API on top of its state, reporting the
+// configurable attributes whose value changed.
+func auditDrift(r *schema.Resource, d *schema.ResourceData, setData func(*schema.ResourceData) error) ([]AuditDrift, error) {
+	attributes := make([]string, 0, len(r.Schema))
+	for attribute, s := range r.Schema {
+		if s.Optional || s.Required {
+			attributes = append(attributes, attribute)
+		}
+	}
+	sort.Strings(attributes)
+
+	before := map[string]interface{}{}
+	for _, attribute := range attributes {
+		before[attribute] = auditValue(d.Get(attribute))
+	}
+
+	if err := setData(d); err != nil {
+		return nil, err
+	}
+
+	var drift []AuditDrift
+	for _, attribute := range attributes {
+		after := auditValue(d.Get(attribute))
+		if reflect.DeepEqual(before[attribute], after) {
+			continue
+		}
+
+		if hasSensitiveValue(r.Schema[attribute]) {
+			before[attribute], after = sensitiveAuditValue, sensitiveAuditValue
+		}
+		drift = append(drift, AuditDrift{ID: d.Id(), Attribute: attribute, State: before[attribute], Remote: after})
+	}
+
+	return drift, nil
+}
+
+// sensitiveAuditValue replaces the secrets in the report.
+const sensitiveAuditValue = "(sensitive value)"
+
+// hasSensitiveValue tells whether the attribute or one of its nested
+// attributes is sensitive.
+func hasSensitiveValue(s *schema.Schema) bool {
+	if s.Sensitive {
+		return true
+	}
+
+	if elem, ok := s.Elem.(*schema.Resource); ok {
+		for _, nested := range elem.Schema {
+			if hasSensitiveValue(nested) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// auditValue makes values comparable and printable, sets being sorted lists.
+func auditValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *schema.Set:
+		list := v.List()
+		sort.Slice(list, func(i, j int) bool { return fmt.Sprint(list[i]) < fmt.Sprint(list[j]) })
+		return list
+	}
+	return value
+}
@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sergo-techhub/updown"
+)
+
+const testAuditState = `{
+  "version": 4,
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "updown_check",
+      "name": "website",
+      "instances": [
+        {
+          "index_key": "prod",
+          "attributes": {
+            "id": "abcd", "url": "https://example.com", "type": "https", "period": 60, "apdex_t": 0.5,
+            "enabled": true, "published": false, "alias": "Website", "http_verb": "GET/HEAD",
+            "recipients": ["email:1", "email:9"], "recipients_mode": "authoritative", "removed_attribute": true
+          }
+        }
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "updown_check",
+      "name": "deleted",
+      "instances": [{"attributes": {"id": "gone", "url": "https://gone.example.com"}}]
+    },
+    {
+      "mode": "data",
+      "type": "updown_nodes",
+      "name": "all",
+      "instances": [{"attributes": {"id": "nodes"}}]
+    }
+  ]
+}`
+
+func TestAudit(t *testing.T) {
+	client := newTestClient(t, map[string]interface{}{
+		"checks": []updown.Check{
+			{Token: "abcd", URL: "https://example.com", Alias: "Website", Type: "https", Period: 300, Apdex: 0.5, Enabled: true, HttpVerb: "GET", RecipientIDs: []string{"email:1"}},
+			{Token: "efgh", URL: "https://admin.example.com", Type: "https"},
+		},
+		"recipients": []updown.Recipient{
+			{ID: "email:1", Type: "email", Value: "ops@example.com", Name: "ops@example.com"},
+			{ID: "slack:2", Type: "slack", Name: "#alerts"},
+		},
+		"status_pages": []updown.StatusPage{},
+	})
+
+	report, err := Audit(client, []byte(testAuditState))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := AuditReport{
+		Unmanaged: []AuditObject{
+			{Type: "updown_check", ID: "efgh", Description: "https://admin.example.com"},
+			{Type: "updown_recipient", ID: "email:1", Description: "ops@example.com"},
+		},
+		Deleted: []AuditObject{
+			{Type: "updown_check", ID: "gone", Address: "updown_check.deleted"},
+		},
+		Drift: []AuditDrift{
+			{Address: `updown_check.website["prod"]`, ID: "abcd", Attribute: "period", State: 60, Remote: 300},
+			{Address: `updown_check.website["prod"]`, ID: "abcd", Attribute: "recipients", State: []interface{}{"email:1", "email:9"}, Remote: []interface{}{"email:1"}},
+		},
+		MissingRecipients: []AuditMissingRecipient{
+			{Address: `updown_check.website["prod"]`, ID: "abcd", Recipient: "email:9"},
+		},
+	}
+
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("got %+v, expected %+v", report, expected)
+	}
+}
+
+func TestAudit_stateVersion(t *testing.T) {
+	if _, err := Audit(updown.NewClient("test", nil), []byte(`{"version": 3}`)); err == nil {
+		t.Error("expected an error for an unsupported state version")
+	}
+}
@@ -0,0 +1,383 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sergo-techhub/terraform-provider-updown/internal/provider"
+	"github.com/sergo-techhub/updown"
+)
+
+// BackupVersion is the version of the backup format written by NewBackup. It is
+// increased on incompatible changes only, new fields being optional.
+const BackupVersion = 1
+
+// Backup is a snapshot of the configuration of an account, independent of
+// the Terraform state.
+type Backup struct {
+	Version     int                `json:"version"`
+	CreatedAt   time.Time          `json:"created_at"`
+	Checks      []BackupCheck      `json:"checks"`
+	Recipients  []BackupRecipient  `json:"recipients"`
+	StatusPages []BackupStatusPage `json:"status_pages"`
+}
+
+// BackupCheck holds the configuration of a check. The URL keeps the basic
+// authentication credentials, if any.
+type BackupCheck struct {
+	Token             string            `json:"token"`
+	URL               string            `json:"url"`
+	Type              string            `json:"type"`
+	Alias             string            `json:"alias,omitempty"`
+	Period            int               `json:"period"`
+	Apdex             float64           `json:"apdex_t"`
+	Enabled           bool              `json:"enabled"`
+	Published         bool              `json:"published"`
+	StringMatch       string            `json:"string_match,omitempty"`
+	MuteUntil         string            `json:"mute_until,omitempty"`
+	DisabledLocations []string          `json:"disabled_locations,omitempty"`
+	CustomHeaders     map[string]string `json:"custom_headers,omitempty"`
+	HTTPVerb          string            `json:"http_verb,omitempty"`
+	HTTPBody          string            `json:"http_body,omitempty"`
+	Recipients        []string          `json:"recipients,omitempty"`
+}
+
+// BackupRecipient holds a recipient, referenced by ID by the checks.
+type BackupRecipient struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Value string `json:"value,omitempty"`
+	Name  string `json:"name,omitempty"`
+}
+
+// BackupStatusPage holds a status page, the checks being referenced by token.
+type BackupStatusPage struct {
+	Token       string   `json:"token"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Visibility  string   `json:"visibility"`
+	AccessKey   string   `json:"access_key,omitempty"`
+	Checks      []string `json:"checks,omitempty"`
+}
+
+// NewBackup reads every check, recipient and status page of the account.
+func NewBackup(client *updown.Client) (Backup, error) {
+	checks, _, err := client.Check.List()
+	if err != nil {
+		return Backup{}, fmt.Errorf("reading checks from the API: %w", err)
+	}
+
+	recipients, _, err := client.Recipient.List()
+	if err != nil {
+		return Backup{}, fmt.Errorf("reading recipients from the API: %w", err)
+	}
+
+	statusPages, _, err := client.StatusPage.List()
+	if err != nil {
+		return Backup{}, fmt.Errorf("reading status pages from the API: %w", err)
+	}
+
+	backup := Backup{
+		Version:     BackupVersion,
+		CreatedAt:   time.Now().UTC().Truncate(time.Second),
+		Checks:      make([]BackupCheck, 0, len(checks)),
+		Recipients:  make([]BackupRecipient, 0, len(recipients)),
+		StatusPages: make([]BackupStatusPage, 0, len(statusPages)),
+	}
+
+	for _, check := range checks {
+		backup.Checks = append(backup.Checks, BackupCheck{
+			Token:             check.Token,
+			URL:               check.URL,
+			Type:              check.Type,
+			Alias:             check.Alias,
+			Period:            check.Period,
+			Apdex:             check.Apdex,
+			Enabled:           check.Enabled,
+			Published:         check.Published,
+			StringMatch:       check.StringMatch,
+			MuteUntil:         check.MuteUntil,
+			DisabledLocations: check.DisabledLocations,
+			CustomHeaders:     check.CustomHeaders,
+			HTTPVerb:          check.HttpVerb,
+			HTTPBody:          check.HttpBody,
+			Recipients:        check.RecipientIDs,
+		})
+	}
+
+	for _, r := range recipients {
+		// The API only returns the value as the name of the recipients
+		// without a custom one
+		value := r.Value
+		if value == "" {
+			value = r.Name
+		}
+		backup.Recipients = append(backup.Recipients, BackupRecipient{ID: r.ID, Type: string(r.Type), Value: value, Name: r.Name})
+	}
+
+	for _, statusPage := range statusPages {
+		backup.StatusPages = append(backup.StatusPages, BackupStatusPage{
+			Token:       statusPage.Token,
+			Name:        statusPage.Name,
+			Description: statusPage.Description,
+			Visibility:  statusPage.Visibility,
+			AccessKey:   statusPage.AccessKey,
+			Checks:      statusPage.Checks,
+		})
+	}
+
+	return backup, nil
+}
+
+// RestoreOptions configures Restore.
+type RestoreOptions struct {
+	// DryRun prints what would be created without creating anything.
+	DryRun bool
+
+	// Resume restores into an account which isn't empty, e.g. after a
+	// restore which failed midway, the objects of the backup which already
+	// exist being kept instead of created again.
+	Resume bool
+}
+
+// Restore recreates the objects of the backup in an empty account, or in a
+// partially restored one when resuming, the recipients of the checks and the
+// checks of the status pages being rewired to the new IDs. Every step is written to w.
+func Restore(client *updown.Client, backup Backup, opts RestoreOptions, w io.Writer) error {
+	if backup.Version != BackupVersion {
+		return fmt.Errorf("unsupported backup version %d, expected %d", backup.Version, BackupVersion)
+	}
+
+	existing := restoredObjects{recipients: map[string]string{}, checks: map[string]string{}, statusPages: map[string]string{}}
+	if opts.Resume {
+		var err error
+		if existing, err = listRestoredObjects(client); err != nil {
+			return err
+		}
+	} else if err := checkEmptyAccount(client); err != nil {
+		return err
+	}
+
+	verb := "Created"
+	if opts.DryRun {
+		verb = "Would create"
+	}
+
+	// New IDs by old ID, placeholders being used in dry run mode
+	recipientIDs := map[string]string{}
+	for _, r := range backup.Recipients {
+		// The other types can't be set up through the API
+		if !slices.Contains(provider.ManagedRecipientTypes(), r.Type) {
+			fmt.Fprintf(w, "Skipped recipient %s (%s %s), it has to be set up again through the web UI\n", r.ID, r.Type, r.Name)
+			continue
+		}
+
+		// Backups made by earlier versions don't hold the value of the
+		// recipients without a custom name
+		value := r.Value
+		if value == "" {
+			value = r.Name
+		}
+
+		if id, ok := existing.recipients[recipientKey(r.Type, value)]; ok {
+			recipientIDs[r.ID] = id
+			fmt.Fprintf(w, "Kept existing recipient %s (%s %s) replacing %s\n", id, r.Type, value, r.ID)
+			continue
+		}
+
+		name := r.Name
+		if name == value {
+			name = "" // No custom name
+		}
+
+		newID := "(new " + r.ID + ")"
+		if !opts.DryRun {
+			recipient, _, err := client.Recipient.Add(updown.RecipientItem{Type: updown.RecipientType(r.Type), Value: value, Name: name})
+			if err != nil {
+				return fmt.Errorf("creating recipient %s: %w", r.ID, err)
+			}
+			newID = recipient.ID
+		}
+		recipientIDs[r.ID] = newID
+
+		fmt.Fprintf(w, "%s recipient %s (%s %s) replacing %s\n", verb, newID, r.Type, value, r.ID)
+	}
+
+	checkTokens := map[string]string{}
+	for _, check := range backup.Checks {
+		// Hosts of ICMP checks are configured without the prefix the API adds
+		url := check.URL
+		if check.Type == "icmp" {
+			url = strings.TrimPrefix(url, "icmp://")
+		}
+
+		if token, ok := existing.checks[checkKey(url, check.Alias)]; ok {
+			checkTokens[check.Token] = token
+			fmt.Fprintf(w, "Kept existing check %s (%s) replacing %s\n", token, provider.CheckDisplayURL(url), check.Token)
+			continue
+		}
+
+		recipients := []string{}
+		for _, id := range check.Recipients {
+			if newID, ok := recipientIDs[id]; ok {
+				recipients = append(recipients, newID)
+			} else {
+				fmt.Fprintf(w, "Dropped recipient %s of check %s, it wasn't restored\n", id, check.Token)
+			}
+		}
+
+		newToken := "(new " + check.Token + ")"
+		if !opts.DryRun {
+			item := updown.CheckItem{
+				URL:               url,
+				Type:              check.Type,
+				Alias:             check.Alias,
+				Period:            check.Period,
+				Apdex:             check.Apdex,
+				Enabled:           check.Enabled,
+				Published:         check.Published,
+				StringMatch:       check.StringMatch,
+				MuteUntil:         check.MuteUntil,
+				DisabledLocations: check.DisabledLocations,
+				CustomHeaders:     check.CustomHeaders,
+				HttpVerb:          check.HTTPVerb,
+				HttpBody:          check.HTTPBody,
+			}
+
+			created, err := addCheck(client, item, recipients)
+			if err != nil {
+				return fmt.Errorf("creating check %s: %w", check.Token, err)
+			}
+			newToken = created.Token
+		}
+		checkTokens[check.Token] = newToken
+
+		fmt.Fprintf(w, "%s check %s (%s) replacing %s\n", verb, newToken, provider.CheckDisplayURL(url), check.Token)
+	}
+
+	for _, statusPage := range backup.StatusPages {
+		if token, ok := existing.statusPages[statusPage.Name]; ok {
+			fmt.Fprintf(w, "Kept existing status page %s (%s) replacing %s\n", token, statusPage.Name, statusPage.Token)
+			continue
+		}
+
+		checks := []string{}
+		for _, token := range statusPage.Checks {
+			if newToken, ok := checkTokens[token]; ok {
+				checks = append(checks, newToken)
+			} else {
+				fmt.Fprintf(w, "Dropped check %s of status page %s, it isn't in the backup\n", token, statusPage.Token)
+			}
+		}
+
+		newToken := "(new " + statusPage.Token + ")"
+		if !opts.DryRun {
+			created, _, err := client.StatusPage.Add(updown.StatusPageItem{
+				Name:        statusPage.Name,
+				Description: statusPage.Description,
+				Visibility:  statusPage.Visibility,
+				AccessKey:   statusPage.AccessKey,
+				Checks:      checks,
+			})
+			if err != nil {
+				return fmt.Errorf("creating status page %s: %w", statusPage.Token, err)
+			}
+			newToken = created.Token
+		}
+
+		fmt.Fprintf(w, "%s status page %s (%s) replacing %s\n", verb, newToken, statusPage.Name, statusPage.Token)
+	}
+
+	return nil
+}
+
+// restoredObjects are the IDs of the objects of the account by the key
+// matching them with the objects of a backup: type and value of recipients,
+// URL and alias of checks, and name of status pages.
+type restoredObjects struct {
+	recipients  map[string]string
+	checks      map[string]string
+	statusPages map[string]string
+}
+
+func recipientKey(recipientType, value string) string {
+	return recipientType + "\x00" + strings.ToLower(value)
+}
+
+func checkKey(url, alias string) string {
+	return provider.CheckDisplayURL(url) + "\x00" + alias
+}
+
+// listRestoredObjects reads the objects of the account a restore is resumed
+// in.
+func listRestoredObjects(client *updown.Client) (restoredObjects, error) {
+	objects := restoredObjects{recipients: map[string]string{}, checks: map[string]string{}, statusPages: map[string]string{}}
+
+	checks, _, err := client.Check.List()
+	if err != nil {
+		return objects, fmt.Errorf("reading checks from the API: %w", err)
+	}
+	for _, check := range checks {
+		objects.checks[checkKey(check.URL, check.Alias)] = check.Token
+	}
+
+	recipients, _, err := client.Recipient.List()
+	if err != nil {
+		return objects, fmt.Errorf("reading recipients from the API: %w", err)
+	}
+	for _, r := range recipients {
+		// The API only returns the value as the name of the recipients
+		// without a custom one
+		value := r.Value
+		if value == "" {
+			value = r.Name
+		}
+		objects.recipients[recipientKey(string(r.Type), value)] = r.ID
+	}
+
+	statusPages, _, err := client.StatusPage.List()
+	if err != nil {
+		return objects, fmt.Errorf("reading status pages from the API: %w", err)
+	}
+	for _, statusPage := range statusPages {
+		objects.statusPages[statusPage.Name] = statusPage.Token
+	}
+
+	return objects, nil
+}
+
+// checkEmptyAccount makes sure restoring doesn't duplicate objects of the
+// account. Recipients set up through the web UI are tolerated, as they can't
+// be restored anyway.
+func checkEmptyAccount(client *updown.Client) error {
+	checks, _, err := client.Check.List()
+	if err != nil {
+		return fmt.Errorf("reading checks from the API: %w", err)
+	}
+
+	recipients, _, err := client.Recipient.List()
+	if err != nil {
+		return fmt.Errorf("reading recipients from the API: %w", err)
+	}
+
+	statusPages, _, err := client.StatusPage.List()
+	if err != nil {
+		return fmt.Errorf("reading status pages from the API: %w", err)
+	}
+
+	managedRecipients := 0
+	for _, r := range recipients {
+		if slices.Contains(provider.ManagedRecipientTypes(), string(r.Type)) {
+			managedRecipients++
+		}
+	}
+
+	if len(checks) > 0 || managedRecipients > 0 || len(statusPages) > 0 {
+		return fmt.Errorf("the account isn't empty (%d checks, %d recipients, %d status pages), restoring would duplicate them, resume a restore which failed midway with -resume", len(checks), managedRecipients, len(statusPages))
+	}
+
+	return nil
+}
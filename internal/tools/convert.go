@@ -0,0 +1,163 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/sergo-techhub/terraform-provider-updown/internal/provider"
+)
+
+// ConvertOptions configures the conversion done by Convert.
+type ConvertOptions struct {
+	// From is the tool the monitors are exported from: "uptimerobot",
+	// "pingdom" or "blackbox".
+	From string
+
+	// Export is the JSON export of the UptimeRobot or Pingdom monitors, or the
+	// Prometheus configuration scraping blackbox_exporter.
+	Export []byte
+
+	// BlackboxModules is the blackbox_exporter configuration defining the
+	// modules used by the Prometheus configuration.
+	BlackboxModules []byte
+
+	// Format of the generated configuration: "hcl" or "json".
+	Format string
+}
+
+// Convert turns the monitors exported from another monitoring tool into
+// updown_check resources, entirely offline. The report lists what couldn't be
+// mapped, including the monitors skipped altogether.
+func Convert(opts ConvertOptions) ([]byte, []string, error) {
+	if opts.Format != "hcl" && opts.Format != "json" {
+		return nil, nil, fmt.Errorf("unknown format %q, expected hcl or json", opts.Format)
+	}
+
+	var report conversionReport
+	var checks []convertedCheck
+	var err error
+	switch opts.From {
+	case "uptimerobot":
+		checks, err = convertUptimeRobot(opts.Export, &report)
+	case "pingdom":
+		checks, err = convertPingdom(opts.Export, &report)
+	case "blackbox":
+		checks, err = convertBlackbox(opts.Export, opts.BlackboxModules, &report)
+	default:
+		return nil, nil, fmt.Errorf("unknown source %q, expected uptimerobot, pingdom or blackbox", opts.From)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := &generatedConfig{names: map[string]map[string]bool{}}
+	resources := make([]generatedResource, 0, len(checks))
+	for _, check := range checks {
+		d, err := convertedCheckData(check)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", check.label, err)
+		}
+
+		name := config.resourceName("updown_check", check.label, "check")
+		resources = append(resources, config.resource("updown_check", name, d, provider.CheckResource().Schema, nil))
+	}
+
+	return renderGenerated(opts.Format, resources, config.variables, nil), report, nil
+}
+
+// convertedCheck holds the arguments of the updown_check converted from the
+// monitor of another tool, named label in that tool.
+type convertedCheck struct {
+	label  string
+	values map[string]interface{}
+}
+
+// convertedCheckData sets the arguments of a converted check on top of the
+// schema defaults, which are left out of the generated configuration.
+func convertedCheckData(check convertedCheck) (*schema.ResourceData, error) {
+	r := provider.CheckResource()
+	d := r.Data(nil)
+
+	for attribute, s := range r.Schema {
+		if s.Default != nil {
+			if err := d.Set(attribute, s.Default); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for attribute, value := range check.values {
+		if err := d.Set(attribute, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+// conversionReport lists what couldn't be mapped to updown.
+type conversionReport []string
+
+func (r *conversionReport) add(label, format string, args ...interface{}) {
+	*r = append(*r, label+": "+fmt.Sprintf(format, args...))
+}
+
+// convertPeriod maps an interval in seconds to the closest period supported by
+// updown, reporting when it isn't exactly the same.
+func convertPeriod(label string, seconds int, report *conversionReport) int {
+	if seconds <= 0 {
+		return 60 // Unknown, same default as the schema
+	}
+
+	period := provider.ClosestCheckPeriod(seconds)
+	if period != seconds {
+		report.add(label, "interval of %ds rounded to a period of %ds", seconds, period)
+	}
+	return period
+}
+
+// convertHTTPVerb maps an HTTP method to the http_verb argument, reporting the
+// unsupported ones.
+func convertHTTPVerb(label, method string, report *conversionReport) string {
+	switch method = strings.ToUpper(method); method {
+	case "", "GET", "HEAD":
+		return "GET/HEAD"
+	case "POST", "PUT", "PATCH", "DELETE", "OPTIONS":
+		return method
+	}
+
+	report.add(label, "HTTP method %s isn't supported, GET/HEAD is used instead", method)
+	return "GET/HEAD"
+}
+
+// convertBasicAuth returns the basic_auth block of the credentials, if any.
+func convertBasicAuth(username, password string) []interface{} {
+	if username == "" && password == "" {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{"username": username, "password": password}}
+}
+
+// flexibleInt decodes the numbers which exports write as numbers, numeric
+// strings, empty strings or null.
+type flexibleInt int
+
+func (i *flexibleInt) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		*i = 0
+		return nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("expected a number, got %s", b)
+	}
+	*i = flexibleInt(n)
+	return nil
+}
+
+var _ json.Unmarshaler = new(flexibleInt)
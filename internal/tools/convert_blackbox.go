@@ -0,0 +1,184 @@
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sergo-techhub/terraform-provider-updown/internal/provider"
+	"gopkg.in/yaml.v3"
+)
+
+// prometheusConfig is the part of the Prometheus configuration describing
+// the blackbox_exporter probes.
+type prometheusConfig struct {
+	Global struct {
+		ScrapeInterval string `yaml:"scrape_interval"`
+	} `yaml:"global"`
+	ScrapeConfigs []prometheusScrapeConfig `yaml:"scrape_configs"`
+}
+
+type prometheusScrapeConfig struct {
+	JobName        string              `yaml:"job_name"`
+	ScrapeInterval string              `yaml:"scrape_interval"`
+	MetricsPath    string              `yaml:"metrics_path"`
+	Params         map[string][]string `yaml:"params"`
+	StaticConfigs  []struct {
+		Targets []string `yaml:"targets"`
+	} `yaml:"static_configs"`
+	FileSDConfigs []interface{} `yaml:"file_sd_configs"`
+}
+
+// blackboxConfig is the configuration of blackbox_exporter. The prober
+// settings are kept as maps to report the ones which aren't mapped.
+type blackboxConfig struct {
+	Modules map[string]struct {
+		Prober string                 `yaml:"prober"`
+		HTTP   map[string]interface{} `yaml:"http"`
+		TCP    map[string]interface{} `yaml:"tcp"`
+	} `yaml:"modules"`
+}
+
+func convertBlackbox(prometheus, modules []byte, report *conversionReport) ([]convertedCheck, error) {
+	var scrape prometheusConfig
+	if err := yaml.Unmarshal(prometheus, &scrape); err != nil {
+		return nil, fmt.Errorf("reading Prometheus configuration: %w", err)
+	}
+
+	var blackbox blackboxConfig
+	if err := yaml.Unmarshal(modules, &blackbox); err != nil {
+		return nil, fmt.Errorf("reading blackbox_exporter configuration: %w", err)
+	}
+
+	defaultInterval, err := prometheusDuration(scrape.Global.ScrapeInterval, time.Minute)
+	if err != nil {
+		return nil, fmt.Errorf("reading global scrape_interval: %w", err)
+	}
+
+	var checks []convertedCheck
+	for _, job := range scrape.ScrapeConfigs {
+		// Only the jobs scraping blackbox_exporter are probes
+		if job.MetricsPath != "/probe" {
+			continue
+		}
+
+		interval, err := prometheusDuration(job.ScrapeInterval, defaultInterval)
+		if err != nil {
+			return nil, fmt.Errorf("reading scrape_interval of job %s: %w", job.JobName, err)
+		}
+
+		if len(job.FileSDConfigs) > 0 {
+			report.add(job.JobName, "targets discovered through file_sd_configs aren't supported, only static_configs are converted")
+		}
+
+		moduleName := ""
+		if len(job.Params["module"]) > 0 {
+			moduleName = job.Params["module"][0]
+		}
+		module, ok := blackbox.Modules[moduleName]
+		if !ok {
+			report.add(job.JobName, "module %q isn't defined in the blackbox_exporter configuration, skipped", moduleName)
+			continue
+		}
+
+		for _, static := range job.StaticConfigs {
+			for _, target := range static.Targets {
+				values := map[string]interface{}{
+					"period": convertPeriod(target, int(interval.Seconds()), report),
+				}
+
+				switch module.Prober {
+				case "http":
+					if !strings.Contains(target, "://") {
+						target = "http://" + target // Same default as blackbox_exporter
+					}
+					values["url"] = target
+					values["type"] = provider.InferCheckType(target)
+					convertBlackboxHTTP(target, module.HTTP, values, report)
+				case "tcp":
+					values["url"] = "tcp://" + target
+					values["type"] = "tcp"
+					if tls, _ := module.TCP["tls"].(bool); tls {
+						values["url"], values["type"] = "tcps://"+target, "tcps"
+					}
+					reportUnmappedSettings(target, "tcp", module.TCP, report, "tls")
+				case "icmp":
+					values["url"] = target
+					values["type"] = "icmp"
+				default:
+					report.add(target, "%s probes aren't supported, skipped", module.Prober)
+					continue
+				}
+
+				checks = append(checks, convertedCheck{label: target, values: values})
+			}
+		}
+	}
+
+	return checks, nil
+}
+
+// convertBlackboxHTTP maps the settings of an http prober.
+func convertBlackboxHTTP(label string, settings map[string]interface{}, values map[string]interface{}, report *conversionReport) {
+	if method, ok := settings["method"].(string); ok {
+		values["http_verb"] = convertHTTPVerb(label, method, report)
+	}
+
+	if body, ok := settings["body"].(string); ok {
+		values["http_body"] = body
+	}
+
+	if headers, ok := settings["headers"].(map[string]interface{}); ok {
+		customHeaders := map[string]string{}
+		for name, value := range headers {
+			customHeaders[name] = fmt.Sprint(value)
+		}
+		values["custom_headers"] = customHeaders
+	}
+
+	if auth, ok := settings["basic_auth"].(map[string]interface{}); ok {
+		username, _ := auth["username"].(string)
+		password, _ := auth["password"].(string)
+		values["basic_auth"] = convertBasicAuth(username, password)
+	}
+
+	// updown only looks for a plain string
+	if patterns, ok := settings["fail_if_body_not_matches_regexp"].([]interface{}); ok && len(patterns) > 0 {
+		pattern := fmt.Sprint(patterns[0])
+		if len(patterns) == 1 && regexp.QuoteMeta(pattern) == pattern {
+			values["string_match"] = pattern
+		} else {
+			report.add(label, "only a single fail_if_body_not_matches_regexp without special characters can be mapped to string_match, ignored")
+		}
+	}
+
+	reportUnmappedSettings(label, "http", settings, report, "method", "body", "headers", "basic_auth", "fail_if_body_not_matches_regexp")
+}
+
+// reportUnmappedSettings reports the prober settings other than the mapped
+// ones.
+func reportUnmappedSettings(label, prober string, settings map[string]interface{}, report *conversionReport, mapped ...string) {
+	var unmapped []string
+	for name := range settings {
+		if !slices.Contains(mapped, name) {
+			unmapped = append(unmapped, prober+"."+name)
+		}
+	}
+	sort.Strings(unmapped)
+
+	if len(unmapped) > 0 {
+		report.add(label, "%s can't be mapped, ignored", strings.Join(unmapped, ", "))
+	}
+}
+
+// prometheusDuration parses a Prometheus duration such as 30s, 5m or 1h, the
+// fallback being used when it's empty.
+func prometheusDuration(value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pingdomExport is the response of the /checks endpoint of the Pingdom API,
+// or of /checks/{checkid} for a single check.
+type pingdomExport struct {
+	Checks []pingdomCheck `json:"checks"`
+	Check  *pingdomCheck  `json:"check"`
+}
+
+type pingdomCheck struct {
+	Name       string      `json:"name"`
+	Hostname   string      `json:"hostname"`
+	Resolution flexibleInt `json:"resolution"`
+	Status     string      `json:"status"`
+
+	// Type is the name of the type in the list of checks, and an object
+	// holding the details by type name for a single check.
+	Type json.RawMessage `json:"type"`
+}
+
+type pingdomCheckDetails struct {
+	URL              string            `json:"url"`
+	Encryption       bool              `json:"encryption"`
+	Port             flexibleInt       `json:"port"`
+	ShouldContain    string            `json:"shouldcontain"`
+	ShouldNotContain string            `json:"shouldnotcontain"`
+	PostData         string            `json:"postdata"`
+	RequestHeaders   map[string]string `json:"requestheaders"`
+	Username         string            `json:"username"`
+	Password         string            `json:"password"`
+}
+
+func convertPingdom(export []byte, report *conversionReport) ([]convertedCheck, error) {
+	var pingdom pingdomExport
+	if err := json.Unmarshal(export, &pingdom); err != nil {
+		return nil, fmt.Errorf("reading Pingdom export: %w", err)
+	}
+	if pingdom.Check != nil {
+		pingdom.Checks = append(pingdom.Checks, *pingdom.Check)
+	}
+
+	var checks []convertedCheck
+	for _, check := range pingdom.Checks {
+		label := check.Name
+		if label == "" {
+			label = check.Hostname
+		}
+
+		checkType, details, err := pingdomCheckType(check.Type)
+		if err != nil {
+			return nil, fmt.Errorf("reading Pingdom check %s: %w", label, err)
+		}
+		if details == nil {
+			report.add(label, "the export has no details about the %s check, export it from /checks/{checkid} to convert them", checkType)
+			details = &pingdomCheckDetails{URL: "/"}
+		}
+
+		values := map[string]interface{}{
+			"alias":   check.Name,
+			"period":  convertPeriod(label, int(check.Resolution)*60, report),
+			"enabled": check.Status != "paused",
+		}
+
+		switch checkType {
+		case "http", "httpcustom":
+			scheme, defaultPort := "http", 80
+			if details.Encryption {
+				scheme, defaultPort = "https", 443
+			}
+
+			host := check.Hostname
+			if details.Port != 0 && int(details.Port) != defaultPort {
+				host = net.JoinHostPort(host, strconv.Itoa(int(details.Port)))
+			}
+
+			values["url"] = scheme + "://" + host + details.URL
+			values["type"] = scheme
+			values["string_match"] = details.ShouldContain
+			values["basic_auth"] = convertBasicAuth(details.Username, details.Password)
+
+			if details.PostData != "" {
+				values["http_verb"] = "POST"
+				values["http_body"] = details.PostData
+			}
+
+			headers := map[string]string{}
+			for name, value := range details.RequestHeaders {
+				// Pingdom always sends its own user agent
+				if strings.EqualFold(name, "User-Agent") && strings.HasPrefix(value, "Pingdom") {
+					continue
+				}
+				headers[name] = value
+			}
+			values["custom_headers"] = headers
+
+			if details.ShouldNotContain != "" {
+				report.add(label, "alerting when %q is found isn't supported, ignored", details.ShouldNotContain)
+			}
+			if checkType == "httpcustom" {
+				report.add(label, "custom HTTP checks are converted to plain HTTP checks of %s", details.URL)
+			}
+		case "tcp":
+			values["url"] = "tcp://" + net.JoinHostPort(check.Hostname, strconv.Itoa(int(details.Port)))
+			values["type"] = "tcp"
+		case "ping":
+			values["url"] = check.Hostname
+			values["type"] = "icmp"
+		default:
+			report.add(label, "%s checks aren't supported, skipped", checkType)
+			continue
+		}
+
+		checks = append(checks, convertedCheck{label: label, values: values})
+	}
+
+	return checks, nil
+}
+
+// pingdomCheckType returns the type of the check, along with its details when
+// the export has them.
+func pingdomCheckType(raw json.RawMessage) (string, *pingdomCheckDetails, error) {
+	var name string
+	if err := json.Unmarshal(raw, &name); err == nil {
+		return name, nil, nil
+	}
+
+	var byType map[string]pingdomCheckDetails
+	if err := json.Unmarshal(raw, &byType); err != nil {
+		return "", nil, fmt.Errorf("unexpected type %s", raw)
+	}
+
+	names := make([]string, 0, len(byType))
+	for name := range byType {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) != 1 {
+		return "", nil, fmt.Errorf("expected a single type, got %s", strings.Join(names, ", "))
+	}
+
+	details := byType[names[0]]
+	return names[0], &details, nil
+}
@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConvert_uptimeRobot(t *testing.T) {
+	export := `{"stat": "ok", "monitors": [
+		{"friendly_name": "Website", "url": "https://example.com", "type": 2, "sub_type": "", "port": "", "keyword_type": 2, "keyword_value": "Welcome", "interval": 300, "status": 2, "http_method": 3, "post_value": "{\"ping\":true}", "custom_http_headers": {"X-Token": "abc"}},
+		{"friendly_name": "Mail", "url": "mail.example.com", "type": 4, "sub_type": 4, "port": "", "interval": 45, "status": 0},
+		{"friendly_name": "Cron", "url": "", "type": 5, "interval": 3600, "status": 1}
+	]}`
+
+	config, report, err := Convert(ConvertOptions{From: "uptimerobot", Export: []byte(export), Format: "hcl"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `resource "updown_check" "website" {
+  alias = "Website"
+  custom_headers = {
+    X-Token = "abc"
+  }
+  http_body    = "{\"ping\":true}"
+  http_verb    = "POST"
+  period       = 300
+  string_match = "Welcome"
+  type         = "https"
+  url          = "https://example.com"
+}
+
+resource "updown_check" "mail" {
+  alias   = "Mail"
+  enabled = false
+  type    = "tcp"
+  url     = "tcp://mail.example.com:25"
+}
+`
+	if string(config) != expected {
+		t.Errorf("got:\n%s\nexpected:\n%s", config, expected)
+	}
+
+	expectedReport := []string{
+		"Mail: interval of 45s rounded to a period of 60s",
+		"Cron: heartbeat monitors aren't supported, skipped",
+	}
+	if !reflect.DeepEqual([]string(report), expectedReport) {
+		t.Errorf("got report %q, expected %q", report, expectedReport)
+	}
+}
+
+func TestConvert_pingdom(t *testing.T) {
+	export := `{"check": {"name": "API", "hostname": "api.example.com", "resolution": 1, "status": "up", "type": {"http": {
+		"url": "/health", "encryption": true, "port": 8443, "shouldcontain": "ok", "shouldnotcontain": "error",
+		"requestheaders": {"User-Agent": "Pingdom.com_bot_version_1.4", "Accept": "application/json"},
+		"username": "monitor", "password": "secret"
+	}}}}`
+
+	config, report, err := Convert(ConvertOptions{From: "pingdom", Export: []byte(export), Format: "hcl"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, expected := range []string{
+		`url          = "https://api.example.com:8443/health"`,
+		`string_match = "ok"`,
+		`password = var.check_api_basic_auth_password`,
+		`Accept = "application/json"`,
+		`variable "check_api_basic_auth_password"`,
+	} {
+		if !strings.Contains(string(config), expected) {
+			t.Errorf("expected %s in:\n%s", expected, config)
+		}
+	}
+
+	if strings.Contains(string(config), "Pingdom.com_bot") {
+		t.Errorf("expected the Pingdom user agent to be dropped:\n%s", config)
+	}
+
+	if len(report) != 1 || !strings.Contains(report[0], `"error"`) {
+		t.Errorf("expected shouldnotcontain to be reported, got %q", report)
+	}
+}
+
+func TestConvert_blackbox(t *testing.T) {
+	prometheus := `
+global:
+  scrape_interval: 30s
+scrape_configs:
+  - job_name: node
+    static_configs:
+      - targets: ["localhost:9100"]
+  - job_name: blackbox_http
+    metrics_path: /probe
+    scrape_interval: 2m
+    params:
+      module: [http_post]
+    static_configs:
+      - targets: ["https://example.com/api", "example.org"]
+  - job_name: blackbox_tcp
+    metrics_path: /probe
+    params:
+      module: [tcp_tls]
+    static_configs:
+      - targets: ["smtp.example.com:465"]
+`
+	modules := `
+modules:
+  http_post:
+    prober: http
+    http:
+      method: POST
+      body: '{}'
+      headers:
+        Content-Type: application/json
+      fail_if_body_not_matches_regexp: ["status.*ok"]
+      valid_status_codes: [200, 201]
+  tcp_tls:
+    prober: tcp
+    tcp:
+      tls: true
+`
+
+	config, report, err := Convert(ConvertOptions{From: "blackbox", Export: []byte(prometheus), BlackboxModules: []byte(modules), Format: "hcl"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, expected := range []string{
+		`resource "updown_check" "example_com_api"`,
+		`url       = "http://example.org"`,
+		`http_verb = "POST"`,
+		`period    = 120`,
+		`url    = "tcps://smtp.example.com:465"`,
+	} {
+		if !strings.Contains(string(config), expected) {
+			t.Errorf("expected %s in:\n%s", expected, config)
+		}
+	}
+
+	if strings.Contains(string(config), "localhost") {
+		t.Errorf("expected the jobs not scraping blackbox_exporter to be ignored:\n%s", config)
+	}
+
+	expectedReport := []string{
+		"https://example.com/api: only a single fail_if_body_not_matches_regexp without special characters can be mapped to string_match, ignored",
+		"https://example.com/api: http.valid_status_codes can't be mapped, ignored",
+		"http://example.org: only a single fail_if_body_not_matches_regexp without special characters can be mapped to string_match, ignored",
+		"http://example.org: http.valid_status_codes can't be mapped, ignored",
+	}
+	if !reflect.DeepEqual([]string(report), expectedReport) {
+		t.Errorf("got report %q, expected %q", report, expectedReport)
+	}
+}
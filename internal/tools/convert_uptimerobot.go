@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/sergo-techhub/terraform-provider-updown/internal/provider"
+)
+
+// uptimeRobotExport is the response of the getMonitors method of the
+// UptimeRobot API.
+type uptimeRobotExport struct {
+	Monitors []uptimeRobotMonitor `json:"monitors"`
+}
+
+type uptimeRobotMonitor struct {
+	FriendlyName      string            `json:"friendly_name"`
+	URL               string            `json:"url"`
+	Type              flexibleInt       `json:"type"`
+	SubType           flexibleInt       `json:"sub_type"`
+	Port              flexibleInt       `json:"port"`
+	KeywordType       flexibleInt       `json:"keyword_type"`
+	KeywordValue      string            `json:"keyword_value"`
+	Interval          flexibleInt       `json:"interval"`
+	Status            flexibleInt       `json:"status"`
+	HTTPUsername      string            `json:"http_username"`
+	HTTPPassword      string            `json:"http_password"`
+	HTTPMethod        flexibleInt       `json:"http_method"`
+	PostValue         json.RawMessage   `json:"post_value"`
+	CustomHTTPHeaders map[string]string `json:"custom_http_headers"`
+}
+
+// UptimeRobot monitor types
+const (
+	uptimeRobotHTTP      = 1
+	uptimeRobotKeyword   = 2
+	uptimeRobotPing      = 3
+	uptimeRobotPort      = 4
+	uptimeRobotHeartbeat = 5
+)
+
+// uptimeRobotMethods are the HTTP methods by http_method code.
+var uptimeRobotMethods = map[flexibleInt]string{1: "HEAD", 2: "GET", 3: "POST", 4: "PUT", 5: "PATCH", 6: "DELETE", 7: "OPTIONS"}
+
+// uptimeRobotPorts are the ports of the predefined port monitor sub types,
+// the custom one (99) using the port of the monitor.
+var uptimeRobotPorts = map[flexibleInt]int{1: 80, 2: 443, 3: 21, 4: 25, 5: 110, 6: 143}
+
+func convertUptimeRobot(export []byte, report *conversionReport) ([]convertedCheck, error) {
+	var monitors uptimeRobotExport
+	if err := json.Unmarshal(export, &monitors); err != nil {
+		return nil, fmt.Errorf("reading UptimeRobot export: %w", err)
+	}
+
+	var checks []convertedCheck
+	for _, monitor := range monitors.Monitors {
+		label := monitor.FriendlyName
+		if label == "" {
+			label = monitor.URL
+		}
+
+		values := map[string]interface{}{
+			"alias":   monitor.FriendlyName,
+			"period":  convertPeriod(label, int(monitor.Interval), report),
+			"enabled": monitor.Status != 0, // Paused
+		}
+
+		switch monitor.Type {
+		case uptimeRobotHTTP, uptimeRobotKeyword:
+			values["url"] = monitor.URL
+			values["type"] = provider.InferCheckType(monitor.URL)
+			values["http_verb"] = convertHTTPVerb(label, uptimeRobotMethods[monitor.HTTPMethod], report)
+			values["custom_headers"] = monitor.CustomHTTPHeaders
+			values["basic_auth"] = convertBasicAuth(monitor.HTTPUsername, monitor.HTTPPassword)
+
+			if body := uptimeRobotPostValue(monitor.PostValue); body != "" {
+				values["http_body"] = body
+			}
+
+			if monitor.Type == uptimeRobotKeyword {
+				// updown alerts when the string is missing, not when it's found
+				if monitor.KeywordType == 2 {
+					values["string_match"] = monitor.KeywordValue
+				} else {
+					report.add(label, "alerting when keyword %q exists isn't supported, the keyword is ignored", monitor.KeywordValue)
+				}
+			}
+		case uptimeRobotPing:
+			values["url"] = monitor.URL
+			values["type"] = "icmp"
+		case uptimeRobotPort:
+			port, ok := uptimeRobotPorts[monitor.SubType]
+			if !ok {
+				port = int(monitor.Port)
+			}
+			values["url"] = "tcp://" + net.JoinHostPort(monitor.URL, strconv.Itoa(port))
+			values["type"] = "tcp"
+		case uptimeRobotHeartbeat:
+			report.add(label, "heartbeat monitors aren't supported, skipped")
+			continue
+		default:
+			report.add(label, "monitor type %d isn't supported, skipped", monitor.Type)
+			continue
+		}
+
+		checks = append(checks, convertedCheck{label: label, values: values})
+	}
+
+	return checks, nil
+}
+
+// uptimeRobotPostValue returns the body of the monitor, which the API returns
+// as a string or as a JSON object.
+func uptimeRobotPostValue(raw json.RawMessage) string {
+	var body string
+	if err := json.Unmarshal(raw, &body); err == nil {
+		return body
+	}
+
+	if s := strings.TrimSpace(string(raw)); s != "" && s != "null" {
+		return s
+	}
+	return ""
+}
@@ -0,0 +1,398 @@
+// Package tools implements the logic of the subcommands of the provider
+// binary: generating configurations from an account, converting monitors of
+// other tools, auditing a state and backing up an account. The resources are
+// read through the provider package, so that the configurations match.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/sergo-techhub/terraform-provider-updown/internal/provider"
+	"github.com/sergo-techhub/updown"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GenerateOptions configures the configuration generated by Generate.
+type GenerateOptions struct {
+	// Naming is the source of the check resource names: "alias" (falling
+	// back to the URL for checks without alias) or "url".
+	Naming string
+
+	// Filter only keeps the checks whose alias or URL, the recipients whose
+	// name or value and the status pages whose name match, when set.
+	Filter *regexp.Regexp
+
+	// Format of the generated files: "hcl" or "json".
+	Format string
+}
+
+// Generate reads every check, recipient and status page of the account and
+// returns the configuration managing them along with the import blocks
+// bringing them under management, by file name.
+func Generate(client *updown.Client, opts GenerateOptions) (map[string][]byte, error) {
+	switch opts.Naming {
+	case "alias", "url":
+	default:
+		return nil, fmt.Errorf("unknown naming strategy %q, expected alias or url", opts.Naming)
+	}
+
+	var extension string
+	switch opts.Format {
+	case "hcl":
+		extension = ".tf"
+	case "json":
+		extension = ".tf.json"
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected hcl or json", opts.Format)
+	}
+
+	checks, _, err := client.Check.List()
+	if err != nil {
+		return nil, fmt.Errorf("reading checks from the API: %w", err)
+	}
+
+	recipients, _, err := client.Recipient.List()
+	if err != nil {
+		return nil, fmt.Errorf("reading recipients from the API: %w", err)
+	}
+
+	statusPages, _, err := client.StatusPage.List()
+	if err != nil {
+		return nil, fmt.Errorf("reading status pages from the API: %w", err)
+	}
+
+	config, err := generateConfig(client, opts, checks, recipients, statusPages)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+	for name, resources := range map[string][]generatedResource{
+		"checks":       config.checks,
+		"recipients":   config.recipients,
+		"status_pages": config.statusPages,
+	} {
+		if len(resources) > 0 {
+			files[name+extension] = renderGenerated(opts.Format, resources, nil, nil)
+		}
+	}
+
+	if len(config.variables) > 0 {
+		files["variables"+extension] = renderGenerated(opts.Format, nil, config.variables, nil)
+	}
+
+	var imports []generatedResource
+	imports = append(imports, config.checks...)
+	imports = append(imports, config.recipients...)
+	imports = append(imports, config.statusPages...)
+	if len(imports) > 0 {
+		files["imports"+extension] = renderGenerated(opts.Format, nil, nil, imports)
+	}
+
+	return files, nil
+}
+
+// generatedConfig is the configuration of an account, in a format agnostic
+// way.
+type generatedConfig struct {
+	checks      []generatedResource
+	recipients  []generatedResource
+	statusPages []generatedResource
+	variables   []generatedVariable
+
+	// names already taken, by resource type
+	names map[string]map[string]bool
+}
+
+// generatedResource is a resource of the configuration, imported with id.
+type generatedResource struct {
+	typeName string
+	name     string
+	id       string
+	body     generatedBody
+}
+
+// generatedBody holds the arguments and nested blocks of a block.
+type generatedBody struct {
+	attributes []generatedAttribute
+	blocks     []generatedBlock
+}
+
+type generatedAttribute struct {
+	name  string
+	value generatedValue
+}
+
+type generatedBlock struct {
+	typeName string
+	body     generatedBody
+}
+
+// generatedValue is a literal, a reference to another object of the
+// configuration (e.g. updown_check.website.id) or a list of them.
+type generatedValue struct {
+	literal   cty.Value
+	reference string
+	elements  []generatedValue
+	isList    bool
+}
+
+// generatedVariable holds a secret returned by the API, which is kept out of
+// the generated configuration.
+type generatedVariable struct {
+	name        string
+	description string
+}
+
+func (v generatedValue) isReference() bool {
+	return v.reference != ""
+}
+
+// generateConfig turns the objects of the account into resources, their
+// arguments set the same way as when they are read by the provider.
+func generateConfig(client *updown.Client, opts GenerateOptions, checks []updown.Check, recipients []updown.Recipient, statusPages []updown.StatusPage) (*generatedConfig, error) {
+	config := &generatedConfig{names: map[string]map[string]bool{}}
+	matches := func(values ...string) bool {
+		if opts.Filter == nil {
+			return true
+		}
+		return slices.ContainsFunc(values, func(value string) bool {
+			return value != "" && opts.Filter.MatchString(value)
+		})
+	}
+
+	recipientAddresses := map[string]string{}
+	sort.Slice(recipients, func(i, j int) bool { return recipients[i].ID < recipients[j].ID })
+	for _, r := range recipients {
+		// The other types can't be managed by Terraform
+		if !slices.Contains(provider.ManagedRecipientTypes(), string(r.Type)) || !matches(r.Name, r.Value) {
+			continue
+		}
+
+		label := r.Name
+		if label == "" {
+			label = r.Value
+		}
+		name := config.resourceName("updown_recipient", string(r.Type)+"_"+label, "recipient")
+
+		d := provider.RecipientResource().Data(&terraform.InstanceState{ID: r.ID})
+		if err := provider.SetRecipientData(d, r); err != nil {
+			return nil, fmt.Errorf("reading recipient %s: %w", r.ID, err)
+		}
+
+		// The API returns the value as the name of recipients without custom
+		// name, which doesn't need to be configured
+		if d.Get("name") == d.Get("value") {
+			if err := d.Set("name", ""); err != nil {
+				return nil, err
+			}
+		}
+
+		resource := config.resource("updown_recipient", name, d, provider.RecipientResource().Schema, nil)
+		config.recipients = append(config.recipients, resource)
+		recipientAddresses[r.ID] = "updown_recipient." + name + ".id"
+	}
+
+	checkAddresses := map[string]string{}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Token < checks[j].Token })
+	for _, check := range checks {
+		if !matches(check.Alias, provider.CheckDisplayURL(check.URL)) {
+			continue
+		}
+
+		label := provider.CheckDisplayURL(check.URL)
+		if opts.Naming == "alias" && check.Alias != "" {
+			label = check.Alias
+		}
+		name := config.resourceName("updown_check", label, "check")
+
+		// Credentials embedded in the URL are moved to basic_auth, as done
+		// for the checks already using it
+		d := provider.CheckResource().Data(&terraform.InstanceState{ID: check.Token})
+		if err := d.Set("basic_auth", []interface{}{map[string]interface{}{"username": "", "password": ""}}); err != nil {
+			return nil, err
+		}
+		if err := provider.SetCheckData(context.Background(), client, d, check); err != nil {
+			return nil, fmt.Errorf("reading check %s: %w", check.Token, err)
+		}
+
+		resource := config.resource("updown_check", name, d, provider.CheckResource().Schema, map[string]map[string]string{
+			"recipients": recipientAddresses,
+		})
+		config.checks = append(config.checks, resource)
+		checkAddresses[check.Token] = "updown_check." + name + ".id"
+	}
+
+	sort.Slice(statusPages, func(i, j int) bool { return statusPages[i].Token < statusPages[j].Token })
+	for _, statusPage := range statusPages {
+		if !matches(statusPage.Name) {
+			continue
+		}
+
+		label := statusPage.Name
+		if label == "" {
+			label = statusPage.Token
+		}
+		name := config.resourceName("updown_status_page", label, "status_page")
+
+		d := provider.StatusPageResource().Data(&terraform.InstanceState{ID: statusPage.Token})
+		if err := provider.SetStatusPageData(context.Background(), client, d, statusPage); err != nil {
+			return nil, fmt.Errorf("reading status page %s: %w", statusPage.Token, err)
+		}
+
+		resource := config.resource("updown_status_page", name, d, provider.StatusPageResource().Schema, map[string]map[string]string{
+			"checks": checkAddresses,
+		})
+		config.statusPages = append(config.statusPages, resource)
+	}
+
+	return config, nil
+}
+
+var generatedNameSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// resourceName turns a label (alias, URL, name, etc.) into a resource name
+// unique for the resource type.
+func (c *generatedConfig) resourceName(typeName, label, prefix string) string {
+	label = strings.ToLower(label)
+	if _, rest, ok := strings.Cut(label, "://"); ok {
+		label = rest
+	}
+
+	name := strings.Trim(generatedNameSeparators.ReplaceAllString(label, "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = strings.TrimSuffix(prefix+"_"+name, "_")
+	}
+
+	if c.names[typeName] == nil {
+		c.names[typeName] = map[string]bool{}
+	}
+
+	unique := name
+	for i := 2; c.names[typeName][unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	c.names[typeName][unique] = true
+
+	return unique
+}
+
+// resource builds the arguments of a resource from its data, leaving out the
+// computed and default values. The IDs found in the attributes of references
+// are replaced by references to the generated resources.
+func (c *generatedConfig) resource(typeName, name string, d *schema.ResourceData, s map[string]*schema.Schema, references map[string]map[string]string) generatedResource {
+	values := map[string]interface{}{}
+	for attribute := range s {
+		values[attribute] = d.Get(attribute)
+	}
+
+	return generatedResource{
+		typeName: typeName,
+		name:     name,
+		id:       d.Id(),
+		body:     c.body(typeName+"."+name, s, values, references),
+	}
+}
+
+func (c *generatedConfig) body(address string, s map[string]*schema.Schema, values map[string]interface{}, references map[string]map[string]string) generatedBody {
+	var body generatedBody
+
+	attributes := make([]string, 0, len(s))
+	for attribute := range s {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+
+	for _, attribute := range attributes {
+		attributeSchema, value := s[attribute], values[attribute]
+		if attribute == "adopt_existing" || attributeSchema.WriteOnly || (attributeSchema.Computed && !attributeSchema.Optional) || isGeneratedDefault(attributeSchema, value) {
+			continue
+		}
+
+		if elem, ok := attributeSchema.Elem.(*schema.Resource); ok {
+			for _, v := range value.([]interface{}) {
+				body.blocks = append(body.blocks, generatedBlock{
+					typeName: attribute,
+					body:     c.body(address+"."+attribute, elem.Schema, v.(map[string]interface{}), nil),
+				})
+			}
+			continue
+		}
+
+		if attributeSchema.Sensitive {
+			variable := generatedVariable{
+				name:        strings.ReplaceAll(strings.TrimPrefix(address, "updown_"), ".", "_") + "_" + attribute,
+				description: fmt.Sprintf("Value of %s.%s, which isn't written in the generated configuration.", address, attribute),
+			}
+			c.variables = append(c.variables, variable)
+			body.attributes = append(body.attributes, generatedAttribute{attribute, generatedValue{reference: "var." + variable.name}})
+			continue
+		}
+
+		body.attributes = append(body.attributes, generatedAttribute{attribute, generatedLiteral(value, references[attribute])})
+	}
+
+	return body
+}
+
+// isGeneratedDefault tells whether a value can be left out of the
+// configuration.
+func isGeneratedDefault(s *schema.Schema, value interface{}) bool {
+	if s.Default != nil {
+		return reflect.DeepEqual(s.Default, value)
+	}
+
+	switch v := value.(type) {
+	case *schema.Set:
+		return v.Len() == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return reflect.ValueOf(value).IsZero()
+}
+
+// generatedLiteral converts a value of the resource data, replacing the
+// strings found in references.
+func generatedLiteral(value interface{}, references map[string]string) generatedValue {
+	switch v := value.(type) {
+	case string:
+		if address, ok := references[v]; ok {
+			return generatedValue{reference: address}
+		}
+		return generatedValue{literal: cty.StringVal(v)}
+	case int:
+		return generatedValue{literal: cty.NumberIntVal(int64(v))}
+	case float64:
+		return generatedValue{literal: cty.NumberFloatVal(v)}
+	case bool:
+		return generatedValue{literal: cty.BoolVal(v)}
+	case *schema.Set:
+		elements := v.List()
+		sort.Slice(elements, func(i, j int) bool { return fmt.Sprint(elements[i]) < fmt.Sprint(elements[j]) })
+		return generatedLiteral(elements, references)
+	case []interface{}:
+		list := generatedValue{isList: true}
+		for _, element := range v {
+			list.elements = append(list.elements, generatedLiteral(element, references))
+		}
+		return list
+	case map[string]interface{}:
+		m := map[string]cty.Value{}
+		for key, element := range v {
+			m[key] = cty.StringVal(fmt.Sprint(element))
+		}
+		return generatedValue{literal: cty.MapVal(m)}
+	}
+
+	return generatedValue{literal: cty.StringVal(fmt.Sprint(value))}
+}
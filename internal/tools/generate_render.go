@@ -0,0 +1,174 @@
+package tools
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// renderGenerated writes resources, variables and import blocks in the
+// format, either "hcl" or "json".
+func renderGenerated(format string, resources []generatedResource, variables []generatedVariable, imports []generatedResource) []byte {
+	if format == "json" {
+		return renderJSON(resources, variables, imports)
+	}
+	return renderHCL(resources, variables, imports)
+}
+
+func renderHCL(resources []generatedResource, variables []generatedVariable, imports []generatedResource) []byte {
+	f := hclwrite.NewEmptyFile()
+	root := f.Body()
+	appendBlock := func(typeName string, labels []string) *hclwrite.Body {
+		if len(root.Blocks()) > 0 {
+			root.AppendNewline()
+		}
+		return root.AppendNewBlock(typeName, labels).Body()
+	}
+
+	for _, resource := range resources {
+		renderHCLBody(appendBlock("resource", []string{resource.typeName, resource.name}), resource.body)
+	}
+
+	for _, variable := range variables {
+		body := appendBlock("variable", []string{variable.name})
+		body.SetAttributeValue("description", cty.StringVal(variable.description))
+		body.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+		body.SetAttributeValue("sensitive", cty.True)
+	}
+
+	for _, resource := range imports {
+		body := appendBlock("import", nil)
+		body.SetAttributeTraversal("to", generatedTraversal(resource.typeName+"."+resource.name))
+		body.SetAttributeValue("id", cty.StringVal(resource.id))
+	}
+
+	return hclwrite.Format(f.Bytes())
+}
+
+func renderHCLBody(body *hclwrite.Body, generated generatedBody) {
+	for _, attribute := range generated.attributes {
+		body.SetAttributeRaw(attribute.name, hclTokens(attribute.value))
+	}
+
+	for _, block := range generated.blocks {
+		renderHCLBody(body.AppendNewBlock(block.typeName, nil).Body(), block.body)
+	}
+}
+
+func hclTokens(value generatedValue) hclwrite.Tokens {
+	switch {
+	case value.isReference():
+		return hclwrite.TokensForTraversal(generatedTraversal(value.reference))
+	case value.isList:
+		elements := make([]hclwrite.Tokens, 0, len(value.elements))
+		for _, element := range value.elements {
+			elements = append(elements, hclTokens(element))
+		}
+		return hclwrite.TokensForTuple(elements)
+	}
+	return hclwrite.TokensForValue(value.literal)
+}
+
+// generatedTraversal parses a reference like updown_check.website.id.
+func generatedTraversal(reference string) hcl.Traversal {
+	parts := strings.Split(reference, ".")
+
+	traversal := hcl.Traversal{hcl.TraverseRoot{Name: parts[0]}}
+	for _, part := range parts[1:] {
+		traversal = append(traversal, hcl.TraverseAttr{Name: part})
+	}
+	return traversal
+}
+
+func renderJSON(resources []generatedResource, variables []generatedVariable, imports []generatedResource) []byte {
+	document := map[string]interface{}{}
+
+	if len(resources) > 0 {
+		byType := map[string]map[string]interface{}{}
+		for _, resource := range resources {
+			if byType[resource.typeName] == nil {
+				byType[resource.typeName] = map[string]interface{}{}
+			}
+			byType[resource.typeName][resource.name] = jsonBody(resource.body)
+		}
+		document["resource"] = byType
+	}
+
+	if len(variables) > 0 {
+		byName := map[string]interface{}{}
+		for _, variable := range variables {
+			byName[variable.name] = map[string]interface{}{
+				"description": variable.description,
+				"type":        "string",
+				"sensitive":   true,
+			}
+		}
+		document["variable"] = byName
+	}
+
+	if len(imports) > 0 {
+		blocks := make([]interface{}, 0, len(imports))
+		for _, resource := range imports {
+			blocks = append(blocks, map[string]interface{}{
+				"to": resource.typeName + "." + resource.name,
+				"id": resource.id,
+			})
+		}
+		document["import"] = blocks
+	}
+
+	// Can't fail, the document only holds maps, slices and primitives
+	b, _ := json.MarshalIndent(document, "", "  ")
+	return append(b, '\n')
+}
+
+func jsonBody(generated generatedBody) map[string]interface{} {
+	body := map[string]interface{}{}
+	for _, attribute := range generated.attributes {
+		body[attribute.name] = jsonValue(attribute.value)
+	}
+
+	for _, block := range generated.blocks {
+		blocks, _ := body[block.typeName].([]interface{})
+		body[block.typeName] = append(blocks, jsonBody(block.body))
+	}
+
+	return body
+}
+
+// jsonValue converts a value to the JSON syntax, where references are
+// template interpolations and literal strings are escaped accordingly.
+func jsonValue(value generatedValue) interface{} {
+	switch {
+	case value.isReference():
+		return "${" + value.reference + "}"
+	case value.isList:
+		elements := make([]interface{}, 0, len(value.elements))
+		for _, element := range value.elements {
+			elements = append(elements, jsonValue(element))
+		}
+		return elements
+	}
+	return jsonLiteral(value.literal)
+}
+
+func jsonLiteral(value cty.Value) interface{} {
+	switch {
+	case value.Type() == cty.String:
+		return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(value.AsString())
+	case value.Type() == cty.Number:
+		return json.Number(value.AsBigFloat().Text('f', -1))
+	case value.Type() == cty.Bool:
+		return value.True()
+	case value.Type().IsMapType():
+		m := map[string]interface{}{}
+		for key, element := range value.AsValueMap() {
+			m[key] = jsonLiteral(element)
+		}
+		return m
+	}
+	return nil
+}
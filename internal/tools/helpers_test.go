@@ -0,0 +1,34 @@
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/sergo-techhub/updown"
+)
+
+// newHandlerTestClient returns a client of an API served by the handler.
+func newHandlerTestClient(t *testing.T, handler http.HandlerFunc) *updown.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := updown.NewClient("test", nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/api/")
+	return client
+}
+
+// newTestClient returns a client of an API serving the responses by path.
+func newTestClient(t *testing.T, responses map[string]interface{}) *updown.Client {
+	return newHandlerTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		response, ok := responses[strings.TrimPrefix(r.URL.Path, "/api/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	})
+}